@@ -0,0 +1,91 @@
+package dom
+
+import (
+	"slices"
+	"strings"
+
+	"golang.org/x/net/html"
+	"golang.org/x/net/html/atom"
+
+	"github.com/typelate/dom/spec"
+)
+
+const (
+	positionBeforeBegin = "beforebegin"
+	positionAfterBegin  = "afterbegin"
+	positionBeforeEnd   = "beforeend"
+	positionAfterEnd    = "afterend"
+)
+
+// insertAdjacent is based on https://dom.spec.whatwg.org/#insert-adjacent
+// It returns false when the nodes were not inserted because element does not have a parent.
+func insertAdjacent(element *html.Node, position string, nodes []*html.Node) bool {
+	var parent, reference *html.Node
+	switch strings.ToLower(position) {
+	case positionBeforeBegin:
+		parent, reference = element.Parent, element
+	case positionAfterBegin:
+		parent, reference = element, element.FirstChild
+	case positionBeforeEnd:
+		parent, reference = element, nil
+	case positionAfterEnd:
+		parent, reference = element.Parent, element.NextSibling
+	default:
+		panic("dom: syntax error: unknown insert adjacent position " + position)
+	}
+	if parent == nil {
+		return false
+	}
+	for _, n := range nodes {
+		for p := parent; p != nil; p = p.Parent {
+			if p == n {
+				panic("dom: hierarchy request error: a node can not be inserted into itself or its descendants")
+			}
+		}
+	}
+	for reference != nil && slices.Contains(nodes, reference) {
+		reference = reference.NextSibling
+	}
+	for _, n := range nodes {
+		removeHTMLNode(n)
+	}
+	insertHTMLNodes(parent, nodes, reference)
+	return true
+}
+
+func insertAdjacentElement(node *html.Node, position string, element spec.Element) spec.Element {
+	n := domNodeToHTMLNode(element)
+	if !insertAdjacent(node, position, []*html.Node{n}) {
+		return nil
+	}
+	return element
+}
+
+func insertAdjacentText(node *html.Node, position, data string) {
+	insertAdjacent(node, position, []*html.Node{{Type: html.TextNode, Data: data}})
+}
+
+// insertAdjacentHTML is based on https://html.spec.whatwg.org/multipage/dynamic-markup-insertion.html#dom-element-insertadjacenthtml
+// The text is parsed in the context of the parent for "beforebegin" and "afterend" and in the context of the element otherwise.
+func insertAdjacentHTML(node *html.Node, position, text string) {
+	var context *html.Node
+	switch strings.ToLower(position) {
+	case positionBeforeBegin, positionAfterEnd:
+		context = node.Parent
+		if context == nil || context.Type == html.DocumentNode {
+			panic("dom: no modification allowed error: InsertAdjacentHTML requires a parent element for position " + position)
+		}
+	case positionAfterBegin, positionBeforeEnd:
+		context = node
+	default:
+		panic("dom: syntax error: unknown insert adjacent position " + position)
+	}
+	if context.Type != html.ElementNode || (context.Namespace == "" && context.DataAtom == atom.Html) {
+		context = &html.Node{Type: html.ElementNode, Data: atom.Body.String(), DataAtom: atom.Body}
+	}
+	nodes, err := html.ParseFragment(strings.NewReader(text), context)
+	if err != nil {
+		panic(err)
+	}
+	insertAdjacent(node, position, nodes)
+}
@@ -0,0 +1,111 @@
+package dom_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/net/html"
+
+	"github.com/typelate/dom"
+	"github.com/typelate/dom/spec"
+)
+
+func parseAdjacentDocument(t *testing.T) spec.Document {
+	t.Helper()
+	// language=html
+	node, err := html.Parse(strings.NewReader(`<!DOCTYPE html><html><head></head><body><div id="parent"><p id="target"><em>x</em></p></div></body></html>`))
+	require.NoError(t, err)
+	return dom.NewNode(node).(spec.Document)
+}
+
+func TestElement_InsertAdjacentHTML(t *testing.T) {
+	for _, tt := range []struct {
+		Position string
+		Expected string
+	}{
+		{Position: "beforebegin", Expected: `<b>1</b><p id="target"><em>x</em></p>`},
+		{Position: "afterbegin", Expected: `<p id="target"><b>1</b><em>x</em></p>`},
+		{Position: "beforeend", Expected: `<p id="target"><em>x</em><b>1</b></p>`},
+		{Position: "afterend", Expected: `<p id="target"><em>x</em></p><b>1</b>`},
+		{Position: "BeforeEnd", Expected: `<p id="target"><em>x</em><b>1</b></p>`},
+	} {
+		t.Run(tt.Position, func(t *testing.T) {
+			document := parseAdjacentDocument(t)
+			document.QuerySelector("#target").InsertAdjacentHTML(tt.Position, `<b>1</b>`)
+			assert.Equal(t, tt.Expected, document.QuerySelector("#parent").InnerHTML())
+		})
+	}
+	t.Run("context element", func(t *testing.T) {
+		document := parseAdjacentDocument(t)
+		table := document.CreateElement("table")
+		document.Body().Append(table)
+		table.InsertAdjacentHTML("beforeend", `<tr><td>1</td></tr>`)
+		assert.Equal(t, `<tbody><tr><td>1</td></tr></tbody>`, table.InnerHTML())
+
+		tbody := table.FirstElementChild()
+		tbody.InsertAdjacentHTML("afterbegin", `<tr><td>0</td></tr>`)
+		assert.Equal(t, `<tr><td>0</td></tr><tr><td>1</td></tr>`, tbody.InnerHTML())
+	})
+	t.Run("invalid position", func(t *testing.T) {
+		document := parseAdjacentDocument(t)
+		assert.Panics(t, func() {
+			document.QuerySelector("#target").InsertAdjacentHTML("middle", `<b>1</b>`)
+		})
+	})
+	t.Run("no parent", func(t *testing.T) {
+		document := parseAdjacentDocument(t)
+		el := document.CreateElement("div")
+		assert.Panics(t, func() {
+			el.InsertAdjacentHTML("beforebegin", `<b>1</b>`)
+		})
+		assert.Panics(t, func() {
+			document.QuerySelector("html").InsertAdjacentHTML("afterend", `<b>1</b>`)
+		})
+	})
+}
+
+func TestElement_InsertAdjacentElement(t *testing.T) {
+	t.Run("positions", func(t *testing.T) {
+		document := parseAdjacentDocument(t)
+		target := document.QuerySelector("#target")
+		for _, position := range []string{"beforebegin", "afterbegin", "beforeend", "afterend"} {
+			el := document.CreateElement("i")
+			el.Append(document.CreateTextNode(position))
+			assert.True(t, el.IsSameNode(target.InsertAdjacentElement(position, el)))
+		}
+		assert.Equal(t, `<i>beforebegin</i><p id="target"><i>afterbegin</i><em>x</em><i>beforeend</i></p><i>afterend</i>`, document.QuerySelector("#parent").InnerHTML())
+	})
+	t.Run("moves element", func(t *testing.T) {
+		document := parseAdjacentDocument(t)
+		em := document.QuerySelector("em")
+		document.QuerySelector("#target").InsertAdjacentElement("afterend", em)
+		assert.Equal(t, `<p id="target"></p><em>x</em>`, document.QuerySelector("#parent").InnerHTML())
+	})
+	t.Run("no parent", func(t *testing.T) {
+		document := parseAdjacentDocument(t)
+		el := document.CreateElement("div")
+		assert.Nil(t, el.InsertAdjacentElement("afterend", document.CreateElement("span")))
+	})
+	t.Run("hierarchy", func(t *testing.T) {
+		document := parseAdjacentDocument(t)
+		parent := document.QuerySelector("#parent")
+		assert.Panics(t, func() {
+			document.QuerySelector("#target").InsertAdjacentElement("beforeend", parent)
+		})
+	})
+}
+
+func TestElement_InsertAdjacentText(t *testing.T) {
+	document := parseAdjacentDocument(t)
+	target := document.QuerySelector("#target")
+	target.InsertAdjacentText("beforebegin", "a")
+	target.InsertAdjacentText("afterbegin", "<b>")
+	target.InsertAdjacentText("afterend", "c")
+	assert.Equal(t, `a<p id="target">&lt;b&gt;<em>x</em></p>c`, document.QuerySelector("#parent").InnerHTML())
+
+	assert.Panics(t, func() {
+		target.InsertAdjacentText("nowhere", "d")
+	})
+}
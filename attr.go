@@ -0,0 +1,256 @@
+package dom
+
+import (
+	"golang.org/x/net/html"
+
+	"github.com/typelate/dom/spec"
+)
+
+// Attr is a live view of an attribute on an element. Once the attribute is
+// removed from the element, it keeps the last value and namespace it observed.
+type Attr struct {
+	element      *html.Node
+	namespace    string
+	key          string
+	value        string
+	namespaceURI string
+}
+
+func newAttr(element *html.Node, att html.Attribute) *Attr {
+	return &Attr{
+		element:      element,
+		namespace:    att.Namespace,
+		key:          att.Key,
+		value:        att.Val,
+		namespaceURI: attributeNamespaceURI(element, att),
+	}
+}
+
+// detachedAttr returns an attribute node for att that is not set on an element.
+func detachedAttr(element *html.Node, att html.Attribute) *Attr {
+	a := newAttr(element, att)
+	a.element = nil
+	return a
+}
+
+func (a *Attr) NodeType() spec.NodeType { return spec.NodeTypeAttribute }
+func (a *Attr) TextContent() string     { return a.Value() }
+func (a *Attr) Prefix() string          { return a.namespace }
+func (a *Attr) LocalName() string       { return a.key }
+func (a *Attr) Name() string            { return attributeQualifiedName(a.attribute()) }
+
+func (a *Attr) NamespaceURI() string {
+	if a.index() >= 0 {
+		a.namespaceURI = attributeNamespaceURI(a.element, a.attribute())
+	}
+	return a.namespaceURI
+}
+
+func (a *Attr) Value() string {
+	if i := a.index(); i >= 0 {
+		a.value = a.element.Attr[i].Val
+	}
+	return a.value
+}
+
+func (a *Attr) SetValue(value string) {
+	a.value = value
+	if i := a.index(); i >= 0 {
+		mutated(a.element)
+		queueAttributeRecord(a.element, a.element.Attr[i], a.element.Attr[i].Val)
+		a.element.Attr[i].Val = value
+	}
+}
+
+func (a *Attr) OwnerElement() spec.Element {
+	if a.index() < 0 {
+		return nil
+	}
+	return &Element{node: a.element}
+}
+
+func (a *Attr) CloneNode(bool) spec.Node {
+	clone := newAttr(nil, a.attribute())
+	clone.namespaceURI = a.NamespaceURI()
+	return clone
+}
+
+func (a *Attr) IsSameNode(other spec.Node) bool {
+	o, ok := other.(*Attr)
+	if !ok || o == nil {
+		return false
+	}
+	if a == o {
+		return true
+	}
+	return a.element != nil && a.element == o.element && a.namespace == o.namespace && a.key == o.key && a.index() >= 0
+}
+
+func (a *Attr) IsEqualNode(other spec.Node) bool { return isEqualNode(a, other) }
+
+// CompareDocumentPosition is based on https://dom.spec.whatwg.org/#dom-node-comparedocumentposition
+// An attribute is positioned by its owner element. Attributes on the same element are ordered by their index.
+func (a *Attr) CompareDocumentPosition(other spec.Node) spec.DocumentPosition {
+	if a.IsSameNode(other) {
+		return 0
+	}
+	i := a.index()
+	if i < 0 {
+		return spec.DocumentPositionDisconnected | spec.DocumentPositionImplementationSpecific
+	}
+	if o, ok := other.(*Attr); ok {
+		j := o.index()
+		if j < 0 {
+			return spec.DocumentPositionDisconnected | spec.DocumentPositionImplementationSpecific
+		}
+		if o.element == a.element {
+			if j < i {
+				return spec.DocumentPositionImplementationSpecific | spec.DocumentPositionPreceding
+			}
+			return spec.DocumentPositionImplementationSpecific | spec.DocumentPositionFollowing
+		}
+		return compareDocumentPosition(a.element, &Element{node: o.element}) &^ (spec.DocumentPositionContains | spec.DocumentPositionContainedBy)
+	}
+	if isSameNode(a.element, other) {
+		return spec.DocumentPositionContains | spec.DocumentPositionPreceding
+	}
+	return compareDocumentPosition(a.element, other) &^ spec.DocumentPositionContainedBy
+}
+
+func (a *Attr) attribute() html.Attribute {
+	return html.Attribute{Namespace: a.namespace, Key: a.key, Val: a.Value()}
+}
+
+func (a *Attr) index() int {
+	if a.element == nil {
+		return -1
+	}
+	for i, att := range a.element.Attr {
+		if att.Namespace == a.namespace && att.Key == a.key {
+			return i
+		}
+	}
+	return -1
+}
+
+func attributeQualifiedName(att html.Attribute) string {
+	if att.Namespace == "" {
+		return att.Key
+	}
+	return att.Namespace + ":" + att.Key
+}
+
+func domAttr(attr spec.Attr) *Attr {
+	a, ok := attr.(*Attr)
+	if !ok {
+		panic("not implemented")
+	}
+	return a
+}
+
+func getAttributeNode(node *html.Node, name string) spec.Attr {
+	i := attributeIndex(node, name)
+	if i < 0 {
+		return nil
+	}
+	return newAttr(node, node.Attr[i])
+}
+
+// setAttributeNode is based on https://dom.spec.whatwg.org/#concept-element-attributes-set
+// The replaced attribute keeps its position so attribute order is stable.
+func setAttributeNode(node *html.Node, attr spec.Attr) spec.Attr {
+	mutated(node)
+	a := domAttr(attr)
+	if a.element == node && a.index() >= 0 {
+		return a
+	}
+	if a.index() >= 0 {
+		panic("dom: SetAttributeNode called with an attribute in use by another element")
+	}
+	att := a.attribute()
+	namespace := a.NamespaceURI()
+	a.element = node
+	for i, existing := range node.Attr {
+		if existing.Namespace == att.Namespace && existing.Key == att.Key {
+			replaced := detachedAttr(node, existing)
+			queueAttributeRecord(node, existing, existing.Val)
+			storeAttributeNamespace(node, att, namespace)
+			node.Attr[i] = att
+			return replaced
+		}
+	}
+	queueAttributeRecord(node, att, "")
+	storeAttributeNamespace(node, att, namespace)
+	node.Attr = append(node.Attr, att)
+	return nil
+}
+
+func removeAttributeNode(node *html.Node, attr spec.Attr) spec.Attr {
+	mutated(node)
+	a := domAttr(attr)
+	if a.element != node {
+		panic("dom: RemoveAttributeNode called with an attribute not set on the element")
+	}
+	i := a.index()
+	if i < 0 {
+		panic("dom: RemoveAttributeNode called with an attribute not set on the element")
+	}
+	a.value = node.Attr[i].Val
+	a.namespaceURI = attributeNamespaceURI(node, node.Attr[i])
+	a.element = nil
+	removeAttributeAt(node, i)
+	return a
+}
+
+type namedNodeMap struct {
+	node *html.Node
+}
+
+func (m namedNodeMap) Length() int { return len(m.node.Attr) }
+
+func (m namedNodeMap) Item(index int) spec.Attr {
+	if index < 0 || index >= len(m.node.Attr) {
+		return nil
+	}
+	return newAttr(m.node, m.node.Attr[index])
+}
+
+func (m namedNodeMap) GetNamedItem(qualifiedName string) spec.Attr {
+	return getAttributeNode(m.node, qualifiedName)
+}
+
+func (m namedNodeMap) GetNamedItemNS(namespace, localName string) spec.Attr {
+	i := attributeIndexNS(m.node, namespace, localName)
+	if i < 0 {
+		return nil
+	}
+	return newAttr(m.node, m.node.Attr[i])
+}
+
+func (m namedNodeMap) SetNamedItem(attr spec.Attr) spec.Attr {
+	return setAttributeNode(m.node, attr)
+}
+
+func (m namedNodeMap) SetNamedItemNS(attr spec.Attr) spec.Attr {
+	return setAttributeNode(m.node, attr)
+}
+
+func (m namedNodeMap) RemoveNamedItem(qualifiedName string) spec.Attr {
+	i := attributeIndex(m.node, qualifiedName)
+	if i < 0 {
+		return nil
+	}
+	removed := detachedAttr(m.node, m.node.Attr[i])
+	removeAttributeAt(m.node, i)
+	return removed
+}
+
+func (m namedNodeMap) RemoveNamedItemNS(namespace, localName string) spec.Attr {
+	i := attributeIndexNS(m.node, namespace, localName)
+	if i < 0 {
+		return nil
+	}
+	removed := detachedAttr(m.node, m.node.Attr[i])
+	removeAttributeAt(m.node, i)
+	return removed
+}
@@ -0,0 +1,205 @@
+package dom
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/typelate/dom/spec"
+)
+
+var (
+	_ spec.Attr         = (*Attr)(nil)
+	_ spec.NamedNodeMap = namedNodeMap{}
+)
+
+func TestElement_SetAttribute(t *testing.T) {
+	t.Run("update existing", func(t *testing.T) {
+		// language=html
+		_, el := parseDocument(t, `<!DOCTYPE html><html><head></head><body><div id="a" class="x" title="t"></div></body></html>`, "#a")
+
+		el.SetAttribute("CLASS", "y")
+
+		assert.Equal(t, "y", el.GetAttribute("class"))
+		assert.Equal(t, `<div id="a" class="y" title="t"></div>`, el.OuterHTML())
+	})
+	t.Run("add new", func(t *testing.T) {
+		// language=html
+		_, el := parseDocument(t, `<!DOCTYPE html><html><head></head><body><div id="a"></div></body></html>`, "#a")
+
+		el.SetAttribute("title", "t")
+
+		assert.Equal(t, `<div id="a" title="t"></div>`, el.OuterHTML())
+	})
+}
+
+func TestElement_RemoveAttribute(t *testing.T) {
+	// language=html
+	_, el := parseDocument(t, `<!DOCTYPE html><html><head></head><body><div id="a" class="x" title="t"></div></body></html>`, "#a")
+
+	el.RemoveAttribute("Class")
+	el.RemoveAttribute("missing")
+
+	assert.False(t, el.HasAttribute("class"))
+	assert.Equal(t, `<div id="a" title="t"></div>`, el.OuterHTML())
+}
+
+func TestElement_Attributes(t *testing.T) {
+	// language=html
+	_, el := parseDocument(t, `<!DOCTYPE html><html><head></head><body><div id="a" class="x" data-type="post"></div></body></html>`, "#a")
+
+	attributes := el.Attributes()
+	require.Equal(t, 3, attributes.Length())
+
+	var names, values []string
+	for i := 0; i < attributes.Length(); i++ {
+		attr := attributes.Item(i)
+		names = append(names, attr.Name())
+		values = append(values, attr.Value())
+	}
+	assert.Equal(t, []string{"id", "class", "data-type"}, names)
+	assert.Equal(t, []string{"a", "x", "post"}, values)
+	assert.Nil(t, attributes.Item(3))
+	assert.Nil(t, attributes.Item(-1))
+
+	t.Run("live", func(t *testing.T) {
+		el.SetAttribute("title", "t")
+		assert.Equal(t, 4, attributes.Length())
+		assert.Equal(t, "title", attributes.Item(3).Name())
+	})
+	t.Run("GetNamedItem", func(t *testing.T) {
+		attr := attributes.GetNamedItem("CLASS")
+		require.NotNil(t, attr)
+		assert.Equal(t, "x", attr.Value())
+		assert.Nil(t, attributes.GetNamedItem("missing"))
+	})
+	t.Run("RemoveNamedItem", func(t *testing.T) {
+		removed := attributes.RemoveNamedItem("title")
+		require.NotNil(t, removed)
+		assert.Equal(t, "t", removed.Value())
+		assert.Nil(t, removed.OwnerElement())
+		assert.False(t, el.HasAttribute("title"))
+		assert.Nil(t, attributes.RemoveNamedItem("title"))
+	})
+	t.Run("SetNamedItem", func(t *testing.T) {
+		attr := attributes.GetNamedItem("class").CloneNode(false).(spec.Attr)
+		attr.SetValue("z")
+		old := attributes.SetNamedItem(attr)
+		require.NotNil(t, old)
+		assert.Equal(t, "x", old.Value())
+		assert.Equal(t, "z", el.GetAttribute("class"))
+		assert.Equal(t, "class", attributes.Item(1).Name())
+	})
+}
+
+func TestElement_GetAttributeNode(t *testing.T) {
+	// language=html
+	_, el := parseDocument(t, `<!DOCTYPE html><html><head></head><body><div id="a" class="x"></div></body></html>`, "#a")
+
+	attr := el.GetAttributeNode("class")
+	require.NotNil(t, attr)
+	assert.Equal(t, spec.NodeTypeAttribute, attr.NodeType())
+	assert.Equal(t, "class", attr.Name())
+	assert.Equal(t, "class", attr.LocalName())
+	assert.Equal(t, "x", attr.Value())
+	assert.Equal(t, "x", attr.TextContent())
+	assert.True(t, attr.OwnerElement().IsSameNode(el))
+	assert.True(t, attr.IsSameNode(el.GetAttributeNode("class")))
+	assert.False(t, attr.IsSameNode(el.GetAttributeNode("id")))
+	assert.False(t, el.IsSameNode(attr))
+
+	t.Run("live value", func(t *testing.T) {
+		el.SetAttribute("class", "y")
+		assert.Equal(t, "y", attr.Value())
+		attr.SetValue("z")
+		assert.Equal(t, "z", el.GetAttribute("class"))
+	})
+
+	assert.Nil(t, el.GetAttributeNode("missing"))
+}
+
+func TestElement_SetAttributeNode(t *testing.T) {
+	t.Run("new attribute", func(t *testing.T) {
+		// language=html
+		document, _ := parseDocument(t, `<!DOCTYPE html><html><head></head><body><div id="a" title="t"></div><div id="b"></div></body></html>`, "")
+		a := document.QuerySelector("#a")
+		b := document.QuerySelector("#b")
+
+		attr := a.RemoveAttributeNode(a.GetAttributeNode("title"))
+		require.Nil(t, attr.OwnerElement())
+
+		old := b.SetAttributeNode(attr)
+		assert.Nil(t, old)
+		assert.Equal(t, `<div id="b" title="t"></div>`, b.OuterHTML())
+		assert.True(t, attr.OwnerElement().IsSameNode(b))
+	})
+	t.Run("replace keeps position", func(t *testing.T) {
+		// language=html
+		_, el := parseDocument(t, `<!DOCTYPE html><html><head></head><body><div id="a" class="x" title="t"></div></body></html>`, "#a")
+
+		attr := el.GetAttributeNode("class").CloneNode(false).(spec.Attr)
+		attr.SetValue("y")
+		old := el.SetAttributeNode(attr)
+		require.NotNil(t, old)
+		assert.Equal(t, "x", old.Value())
+		assert.Nil(t, old.OwnerElement())
+		assert.Equal(t, `<div id="a" class="y" title="t"></div>`, el.OuterHTML())
+	})
+	t.Run("same attribute", func(t *testing.T) {
+		// language=html
+		_, el := parseDocument(t, `<!DOCTYPE html><html><head></head><body><div id="a" class="x"></div></body></html>`, "#a")
+		attr := el.GetAttributeNode("class")
+		assert.True(t, el.SetAttributeNode(attr).IsSameNode(attr))
+		assert.Equal(t, 2, el.Attributes().Length())
+	})
+	t.Run("in use", func(t *testing.T) {
+		// language=html
+		document, _ := parseDocument(t, `<!DOCTYPE html><html><head></head><body><div id="a" class="x"></div><div id="b"></div></body></html>`, "")
+		a := document.QuerySelector("#a")
+		b := document.QuerySelector("#b")
+		assert.Panics(t, func() {
+			b.SetAttributeNode(a.GetAttributeNode("class"))
+		})
+	})
+}
+
+func TestElement_RemoveAttributeNode(t *testing.T) {
+	// language=html
+	document, _ := parseDocument(t, `<!DOCTYPE html><html><head></head><body><div id="a" class="x" title="t"></div><div id="b" class="x"></div></body></html>`, "")
+	a := document.QuerySelector("#a")
+	b := document.QuerySelector("#b")
+
+	attr := a.GetAttributeNode("class")
+	removed := a.RemoveAttributeNode(attr)
+	assert.True(t, removed.IsSameNode(attr))
+	assert.Equal(t, "x", removed.Value())
+	assert.Nil(t, removed.OwnerElement())
+	assert.Equal(t, `<div id="a" title="t"></div>`, a.OuterHTML())
+
+	assert.Panics(t, func() {
+		a.RemoveAttributeNode(b.GetAttributeNode("class"))
+	})
+}
+
+func TestAttr_CompareDocumentPosition(t *testing.T) {
+	// language=html
+	document, _ := parseDocument(t, `<!DOCTYPE html><html><head></head><body><div id="a" class="x"><span id="c"></span></div><div id="b"></div></body></html>`, "")
+	a := document.QuerySelector("#a")
+	b := document.QuerySelector("#b")
+	c := document.QuerySelector("#c")
+	id := a.GetAttributeNode("id")
+	class := a.GetAttributeNode("class")
+
+	assert.Equal(t, spec.DocumentPosition(0), id.CompareDocumentPosition(id))
+	assert.Equal(t, spec.DocumentPositionContains|spec.DocumentPositionPreceding, id.CompareDocumentPosition(a))
+	assert.Equal(t, spec.DocumentPositionContainedBy|spec.DocumentPositionFollowing, a.CompareDocumentPosition(id))
+	assert.Equal(t, spec.DocumentPositionFollowing, id.CompareDocumentPosition(c))
+	assert.Equal(t, spec.DocumentPositionPreceding, c.CompareDocumentPosition(id))
+	assert.Equal(t, spec.DocumentPositionFollowing, id.CompareDocumentPosition(b))
+	assert.Equal(t, spec.DocumentPositionImplementationSpecific|spec.DocumentPositionFollowing, id.CompareDocumentPosition(class))
+	assert.Equal(t, spec.DocumentPositionImplementationSpecific|spec.DocumentPositionPreceding, class.CompareDocumentPosition(id))
+
+	detached := id.CloneNode(false)
+	assert.NotZero(t, id.CompareDocumentPosition(detached)&spec.DocumentPositionDisconnected)
+}
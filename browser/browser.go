@@ -4,6 +4,8 @@ package browser
 
 import (
 	"iter"
+	"reflect"
+	"slices"
 	"syscall/js"
 
 	"github.com/typelate/dom/spec"
@@ -13,10 +15,11 @@ type Node struct {
 	value js.Value
 }
 
-func (n *Node) NodeType() spec.NodeType         { return nodeType(n.value) }
-func (n *Node) CloneNode(deep bool) spec.Node   { return cloneNode(n.value, deep) }
-func (n *Node) IsSameNode(other spec.Node) bool { return isSameNode(n.value, other) }
-func (n *Node) TextContent() string             { return textContent(n.value) }
+func (n *Node) NodeType() spec.NodeType          { return nodeType(n.value) }
+func (n *Node) CloneNode(deep bool) spec.Node    { return cloneNode(n.value, deep) }
+func (n *Node) IsSameNode(other spec.Node) bool  { return isSameNode(n.value, other) }
+func (n *Node) IsEqualNode(other spec.Node) bool { return isEqualNode(n.value, other) }
+func (n *Node) TextContent() string              { return textContent(n.value) }
 
 func (n *Node) CompareDocumentPosition(other spec.Node) spec.DocumentPosition {
 	return compareDocumentPosition(n.value, other)
@@ -37,24 +40,50 @@ func newDocument(value js.Value) spec.Document {
 	return &Document{value: value}
 }
 
-func (d *Document) NodeType() spec.NodeType         { return nodeType(d.value) }
-func (d *Document) CloneNode(deep bool) spec.Node   { return cloneNode(d.value, deep) }
-func (d *Document) IsSameNode(other spec.Node) bool { return isSameNode(d.value, other) }
-func (d *Document) TextContent() string             { return textContent(d.value) }
+func (d *Document) NodeType() spec.NodeType          { return nodeType(d.value) }
+func (d *Document) CloneNode(deep bool) spec.Node    { return cloneNode(d.value, deep) }
+func (d *Document) IsSameNode(other spec.Node) bool  { return isSameNode(d.value, other) }
+func (d *Document) IsEqualNode(other spec.Node) bool { return isEqualNode(d.value, other) }
+func (d *Document) TextContent() string              { return textContent(d.value) }
 
 func (d *Document) CompareDocumentPosition(other spec.Node) spec.DocumentPosition {
 	return compareDocumentPosition(d.value, other)
 }
 
-func (d *Document) Head() spec.Element { return newElement(d.value.Get("head")) }
-func (d *Document) Body() spec.Element { return newElement(d.value.Get("body")) }
+func (d *Document) Doctype() spec.DocumentType { return newDocumentType(d.value.Get("doctype")) }
+
+func (d *Document) GetElementById(id string) spec.Element {
+	return newElement(d.value.Call("getElementById", id))
+}
+
+func (d *Document) DocumentElement() spec.Element { return newElement(d.value.Get("documentElement")) }
+func (d *Document) Head() spec.Element            { return newElement(d.value.Get("head")) }
+func (d *Document) Body() spec.Element            { return newElement(d.value.Get("body")) }
+
+func (d *Document) Title() string         { return d.value.Get("title").String() }
+func (d *Document) SetTitle(title string) { d.value.Set("title", title) }
+
+func (d *Document) Forms() spec.ElementCollection   { return d.collection("forms") }
+func (d *Document) Links() spec.ElementCollection   { return d.collection("links") }
+func (d *Document) Images() spec.ElementCollection  { return d.collection("images") }
+func (d *Document) Scripts() spec.ElementCollection { return d.collection("scripts") }
+func (d *Document) Embeds() spec.ElementCollection  { return d.collection("embeds") }
+
+func (d *Document) collection(name string) spec.ElementCollection {
+	return htmlCollection{value: d.value.Get(name)}
+}
 
 func (d *Document) Contains(other spec.Node) bool { return contains(d.value, other) }
+func (d *Document) Normalize()                    { d.value.Call("normalize") }
 
 func (d *Document) GetElementsByTagName(name string) spec.ElementCollection {
 	return getElementsByTagName(d.value, name)
 }
 
+func (d *Document) GetElementsByTagNameNS(namespace, localName string) spec.ElementCollection {
+	return getElementsByTagNameNS(d.value, namespace, localName)
+}
+
 func (d *Document) GetElementsByClassName(name string) spec.ElementCollection {
 	return getElementsByClassName(d.value, name)
 }
@@ -79,18 +108,59 @@ func (d *Document) CreateElementIs(localName, is string) spec.Element {
 	return createElementIs(d.value, localName, is)
 }
 
+func (d *Document) CreateElementNS(namespace, qualifiedName string) spec.Element {
+	return newElement(d.value.Call("createElementNS", nullableNamespace(namespace), qualifiedName))
+}
+
 func (d *Document) CreateTextNode(text string) spec.Text {
 	return createTextNode(d.value, text)
 }
 
+func (d *Document) CreateComment(data string) spec.Comment {
+	return createComment(d.value, data)
+}
+
+func (d *Document) CreateDocumentFragment() spec.DocumentFragment {
+	return &DocumentFragment{value: d.value.Call("createDocumentFragment")}
+}
+
+func (d *Document) CreateAttribute(localName string) spec.Attr {
+	return newAttr(d.value.Call("createAttribute", localName))
+}
+
+func (d *Document) ImportNode(node spec.Node, deep bool) spec.Node {
+	return NewNode(d.value.Call("importNode", JSValue(node), deep))
+}
+
+func (d *Document) AdoptNode(node spec.Node) spec.Node {
+	return NewNode(d.value.Call("adoptNode", JSValue(node)))
+}
+
+func (d *Document) CreateTreeWalker(root spec.Node, whatToShow spec.WhatToShow, filter spec.NodeFilter) spec.TreeWalker {
+	return &TreeWalker{
+		value:  d.value.Call("createTreeWalker", JSValue(root), uint32(whatToShow), nodeFilter(filter)),
+		filter: filter,
+	}
+}
+
+func (d *Document) CreateNodeIterator(root spec.Node, whatToShow spec.WhatToShow, filter spec.NodeFilter) spec.NodeIterator {
+	return &NodeIterator{
+		value:  d.value.Call("createNodeIterator", JSValue(root), uint32(whatToShow), nodeFilter(filter)),
+		filter: filter,
+	}
+}
+
+func (d *Document) CreateRange() spec.Range { return &Range{value: d.value.Call("createRange")} }
+
 type DocumentFragment struct {
 	value js.Value
 }
 
-func (d *DocumentFragment) NodeType() spec.NodeType         { return nodeType(d.value) }
-func (d *DocumentFragment) CloneNode(deep bool) spec.Node   { return cloneNode(d.value, deep) }
-func (d *DocumentFragment) IsSameNode(other spec.Node) bool { return isSameNode(d.value, other) }
-func (d *DocumentFragment) TextContent() string             { return textContent(d.value) }
+func (d *DocumentFragment) NodeType() spec.NodeType          { return nodeType(d.value) }
+func (d *DocumentFragment) CloneNode(deep bool) spec.Node    { return cloneNode(d.value, deep) }
+func (d *DocumentFragment) IsSameNode(other spec.Node) bool  { return isSameNode(d.value, other) }
+func (d *DocumentFragment) IsEqualNode(other spec.Node) bool { return isEqualNode(d.value, other) }
+func (d *DocumentFragment) TextContent() string              { return textContent(d.value) }
 
 func (d *DocumentFragment) CompareDocumentPosition(other spec.Node) spec.DocumentPosition {
 	return compareDocumentPosition(d.value, other)
@@ -101,10 +171,16 @@ func (d *DocumentFragment) FirstElementChild() spec.Element  { return firstEleme
 func (d *DocumentFragment) LastElementChild() spec.Element   { return lastElementChild(d.value) }
 func (d *DocumentFragment) ChildElementCount() int           { return childElementCount(d.value) }
 
+func (d *DocumentFragment) GetElementById(id string) spec.Element {
+	return newElement(d.value.Call("getElementById", id))
+}
+
 func (d *DocumentFragment) Append(nodes ...spec.Node)          { appendNodes(d.value, nodes) }
 func (d *DocumentFragment) Prepend(nodes ...spec.Node)         { prependNodes(d.value, nodes) }
 func (d *DocumentFragment) ReplaceChildren(nodes ...spec.Node) { replaceChildrenNodes(d.value, nodes) }
 
+func (d *DocumentFragment) Normalize() { d.value.Call("normalize") }
+
 func (d *DocumentFragment) QuerySelector(query string) spec.Element {
 	return querySelector(d.value, query)
 }
@@ -128,10 +204,23 @@ func newElement(value js.Value) spec.Element {
 	return &Element{value: value}
 }
 
-func (e *Element) NodeType() spec.NodeType         { return nodeType(e.value) }
-func (e *Element) CloneNode(deep bool) spec.Node   { return cloneNode(e.value, deep) }
-func (e *Element) IsSameNode(other spec.Node) bool { return isSameNode(e.value, other) }
-func (e *Element) TextContent() string             { return textContent(e.value) }
+func (e *Element) NodeType() spec.NodeType          { return nodeType(e.value) }
+func (e *Element) CloneNode(deep bool) spec.Node    { return cloneNode(e.value, deep) }
+func (e *Element) IsSameNode(other spec.Node) bool  { return isSameNode(e.value, other) }
+func (e *Element) IsEqualNode(other spec.Node) bool { return isEqualNode(e.value, other) }
+func (e *Element) TextContent() string              { return textContent(e.value) }
+func (e *Element) LookupPrefix(namespace string) string {
+	return lookupPrefix(e.value, namespace)
+}
+
+func (e *Element) LookupNamespaceURI(prefix string) string {
+	return lookupNamespaceURI(e.value, prefix)
+}
+
+func (e *Element) IsDefaultNamespace(namespace string) bool {
+	return isDefaultNamespace(e.value, namespace)
+}
+
 func (e *Element) CompareDocumentPosition(other spec.Node) spec.DocumentPosition {
 	return compareDocumentPosition(e.value, other)
 }
@@ -144,6 +233,10 @@ func (e *Element) ParentNode() spec.Node           { return parentNode(e.value)
 func (e *Element) ParentElement() spec.Element     { return parentElement(e.value) }
 func (e *Element) PreviousSibling() spec.ChildNode { return previousSibling(e.value) }
 func (e *Element) NextSibling() spec.ChildNode     { return nextSibling(e.value) }
+func (e *Element) Before(nodes ...spec.Node)       { before(e.value, nodes) }
+func (e *Element) After(nodes ...spec.Node)        { after(e.value, nodes) }
+func (e *Element) ReplaceWith(nodes ...spec.Node)  { replaceWith(e.value, nodes) }
+func (e *Element) Remove()                         { e.value.Call("remove") }
 
 func (e *Element) Children() spec.ElementCollection { return children(e.value) }
 func (e *Element) FirstElementChild() spec.Element  { return firstElementChild(e.value) }
@@ -162,6 +255,10 @@ func (e *Element) GetElementsByTagName(name string) spec.ElementCollection {
 	return getElementsByTagName(e.value, name)
 }
 
+func (e *Element) GetElementsByTagNameNS(namespace, localName string) spec.ElementCollection {
+	return getElementsByTagNameNS(e.value, namespace, localName)
+}
+
 func (e *Element) GetElementsByClassName(name string) spec.ElementCollection {
 	return getElementsByClassName(e.value, name)
 }
@@ -179,6 +276,7 @@ func (e *Element) QuerySelectorSequence(query string) iter.Seq[spec.Element] {
 }
 
 func (e *Element) HasChildNodes() bool { return e.value.Bool() }
+func (e *Element) Normalize()          { e.value.Call("normalize") }
 
 func (e *Element) ChildNodes() spec.NodeList[spec.Node] {
 	nodes := e.value.Get("childNodes")
@@ -207,12 +305,16 @@ func (e *Element) RemoveChild(node spec.ChildNode) spec.ChildNode {
 	return newChildNode(e.value.Call("removeChild", JSValue(node)))
 }
 
+func (e *Element) NamespaceURI() string { return nullableString(e.value.Get("namespaceURI")) }
+func (e *Element) Prefix() string       { return nullableString(e.value.Get("prefix")) }
+func (e *Element) LocalName() string    { return e.value.Get("localName").String() }
+
 func (e *Element) TagName() string   { return e.value.Get("tagName").String() }
 func (e *Element) ID() string        { return e.value.Get("id").String() }
 func (e *Element) ClassName() string { return e.value.Get("className").String() }
 
 func (e *Element) GetAttribute(name string) string {
-	return e.value.Call("getAttribute", name).String()
+	return nullableString(e.value.Call("getAttribute", name))
 }
 func (e *Element) SetAttribute(name, value string) { e.value.Call("setAttribute", name, value) }
 
@@ -220,16 +322,91 @@ func (e *Element) RemoveAttribute(name string) { e.value.Call("removeAttribute",
 func (e *Element) ToggleAttribute(name string) bool {
 	return e.value.Call("toggleAttribute", name).Bool()
 }
+func (e *Element) ToggleAttributeForce(name string, force bool) bool {
+	return e.value.Call("toggleAttribute", name, force).Bool()
+}
 func (e *Element) HasAttribute(name string) bool { return e.value.Call("hasAttribute", name).Bool() }
+func (e *Element) HasAttributes() bool           { return e.value.Call("hasAttributes").Bool() }
+
+func (e *Element) GetAttributeNames() []string {
+	array := e.value.Call("getAttributeNames")
+	names := make([]string, array.Length())
+	for i := range names {
+		names[i] = array.Index(i).String()
+	}
+	return names
+}
+
+func (e *Element) GetAttributeNS(namespace, localName string) string {
+	return nullableString(e.value.Call("getAttributeNS", nullableNamespace(namespace), localName))
+}
+
+func (e *Element) SetAttributeNS(namespace, qualifiedName, value string) {
+	e.value.Call("setAttributeNS", nullableNamespace(namespace), qualifiedName, value)
+}
+
+func (e *Element) RemoveAttributeNS(namespace, localName string) {
+	e.value.Call("removeAttributeNS", nullableNamespace(namespace), localName)
+}
+
+func (e *Element) HasAttributeNS(namespace, localName string) bool {
+	return e.value.Call("hasAttributeNS", nullableNamespace(namespace), localName).Bool()
+}
+
+func (e *Element) Attributes() spec.NamedNodeMap {
+	return namedNodeMap{value: e.value.Get("attributes")}
+}
+
+func (e *Element) GetAttributeNode(name string) spec.Attr {
+	return newAttr(e.value.Call("getAttributeNode", name))
+}
+
+func (e *Element) SetAttributeNode(attr spec.Attr) spec.Attr {
+	return newAttr(e.value.Call("setAttributeNode", JSValue(attr)))
+}
+
+func (e *Element) RemoveAttributeNode(attr spec.Attr) spec.Attr {
+	return newAttr(e.value.Call("removeAttributeNode", JSValue(attr)))
+}
+func (e *Element) ClassList() spec.DOMTokenList { return domTokenList{value: e.value.Get("classList")} }
+func (e *Element) RelList() spec.DOMTokenList   { return domTokenList{value: e.value.Get("relList")} }
+func (e *Element) Dataset() spec.DOMStringMap   { return domStringMap{value: e.value.Get("dataset")} }
+func (e *Element) Style() spec.CSSStyleDeclaration {
+	return cssStyleDeclaration{value: e.value.Get("style")}
+}
+
 func (e *Element) Closest(selector string) spec.Element {
 	return newElement(e.value.Call("closest", selector))
 }
 func (e *Element) Matches(selector string) bool { return e.value.Call("matches", selector).Bool() }
 
+// Content returns nil when the element is not a template element.
+func (e *Element) Content() spec.DocumentFragment {
+	content := e.value.Get("content")
+	if !content.InstanceOf(documentFragmentClass) {
+		return nil
+	}
+	return &DocumentFragment{value: content}
+}
+
 func (e *Element) SetInnerHTML(s string) { e.value.Set("innerHTML", s) }
 func (e *Element) InnerHTML() string     { return e.value.Get("innerHTML").String() }
 func (e *Element) SetOuterHTML(s string) { e.value.Set("innerHTML", s) }
 func (e *Element) OuterHTML() string     { return e.value.Get("outerHTML").String() }
+func (e *Element) SetInnerText(s string) { e.value.Set("innerText", s) }
+func (e *Element) InnerText() string     { return e.value.Get("innerText").String() }
+
+func (e *Element) InsertAdjacentElement(position string, element spec.Element) spec.Element {
+	return newElement(e.value.Call("insertAdjacentElement", position, JSValue(element)))
+}
+
+func (e *Element) InsertAdjacentText(position, data string) {
+	e.value.Call("insertAdjacentText", position, data)
+}
+
+func (e *Element) InsertAdjacentHTML(position, text string) {
+	e.value.Call("insertAdjacentHTML", position, text)
+}
 
 type Text struct {
 	value js.Value
@@ -242,11 +419,24 @@ func newTextNode(v js.Value) spec.Text {
 	return &Text{value: v}
 }
 
-func (t *Text) NodeType() spec.NodeType         { return nodeType(t.value) }
-func (t *Text) CloneNode(deep bool) spec.Node   { return cloneNode(t.value, deep) }
-func (t *Text) IsSameNode(other spec.Node) bool { return isSameNode(t.value, other) }
-func (t *Text) TextContent() string             { return textContent(t.value) }
-func (t *Text) Length() int                     { return t.value.Length() }
+func (t *Text) NodeType() spec.NodeType          { return nodeType(t.value) }
+func (t *Text) CloneNode(deep bool) spec.Node    { return cloneNode(t.value, deep) }
+func (t *Text) IsSameNode(other spec.Node) bool  { return isSameNode(t.value, other) }
+func (t *Text) IsEqualNode(other spec.Node) bool { return isEqualNode(t.value, other) }
+func (t *Text) TextContent() string              { return textContent(t.value) }
+func (t *Text) Length() int                      { return t.value.Length() }
+
+func (t *Text) LookupPrefix(namespace string) string {
+	return lookupPrefix(t.value, namespace)
+}
+
+func (t *Text) LookupNamespaceURI(prefix string) string {
+	return lookupNamespaceURI(t.value, prefix)
+}
+
+func (t *Text) IsDefaultNamespace(namespace string) bool {
+	return isDefaultNamespace(t.value, namespace)
+}
 
 func (t *Text) CompareDocumentPosition(other spec.Node) spec.DocumentPosition {
 	return compareDocumentPosition(t.value, other)
@@ -258,13 +448,287 @@ func (t *Text) ParentNode() spec.Node           { return parentNode(t.value) }
 func (t *Text) ParentElement() spec.Element     { return parentElement(t.value) }
 func (t *Text) PreviousSibling() spec.ChildNode { return previousSibling(t.value) }
 func (t *Text) NextSibling() spec.ChildNode     { return nextSibling(t.value) }
+func (t *Text) Before(nodes ...spec.Node)       { before(t.value, nodes) }
+func (t *Text) After(nodes ...spec.Node)        { after(t.value, nodes) }
+func (t *Text) ReplaceWith(nodes ...spec.Node)  { replaceWith(t.value, nodes) }
+func (t *Text) Remove()                         { t.value.Call("remove") }
 
 func (t *Text) Data() string     { return t.value.Get("data").String() }
 func (t *Text) SetData(s string) { t.value.Set("data", s) }
 
+func (t *Text) AppendData(data string)             { t.value.Call("appendData", data) }
+func (t *Text) InsertData(offset int, data string) { t.value.Call("insertData", offset, data) }
+func (t *Text) DeleteData(offset, count int)       { t.value.Call("deleteData", offset, count) }
+
+func (t *Text) ReplaceData(offset, count int, data string) {
+	t.value.Call("replaceData", offset, count, data)
+}
+
+func (t *Text) SubstringData(offset, count int) string {
+	return t.value.Call("substringData", offset, count).String()
+}
+
+func (t *Text) SplitText(offset int) spec.Text {
+	return newTextNode(t.value.Call("splitText", offset))
+}
+
+func (t *Text) WholeText() string { return t.value.Get("wholeText").String() }
+
+type Comment struct {
+	value js.Value
+}
+
+func newComment(v js.Value) spec.Comment {
+	if v.IsNull() {
+		return nil
+	}
+	return &Comment{value: v}
+}
+
+func (c *Comment) NodeType() spec.NodeType          { return nodeType(c.value) }
+func (c *Comment) CloneNode(deep bool) spec.Node    { return cloneNode(c.value, deep) }
+func (c *Comment) IsSameNode(other spec.Node) bool  { return isSameNode(c.value, other) }
+func (c *Comment) IsEqualNode(other spec.Node) bool { return isEqualNode(c.value, other) }
+func (c *Comment) TextContent() string              { return textContent(c.value) }
+func (c *Comment) Length() int                      { return c.value.Get("length").Int() }
+
+func (c *Comment) LookupPrefix(namespace string) string {
+	return lookupPrefix(c.value, namespace)
+}
+
+func (c *Comment) LookupNamespaceURI(prefix string) string {
+	return lookupNamespaceURI(c.value, prefix)
+}
+
+func (c *Comment) IsDefaultNamespace(namespace string) bool {
+	return isDefaultNamespace(c.value, namespace)
+}
+
+func (c *Comment) CompareDocumentPosition(other spec.Node) spec.DocumentPosition {
+	return compareDocumentPosition(c.value, other)
+}
+
+func (c *Comment) IsConnected() bool               { return isConnected(c.value) }
+func (c *Comment) OwnerDocument() spec.Document    { return ownerDocument(c.value) }
+func (c *Comment) ParentNode() spec.Node           { return parentNode(c.value) }
+func (c *Comment) ParentElement() spec.Element     { return parentElement(c.value) }
+func (c *Comment) PreviousSibling() spec.ChildNode { return previousSibling(c.value) }
+func (c *Comment) NextSibling() spec.ChildNode     { return nextSibling(c.value) }
+func (c *Comment) Before(nodes ...spec.Node)       { before(c.value, nodes) }
+func (c *Comment) After(nodes ...spec.Node)        { after(c.value, nodes) }
+func (c *Comment) ReplaceWith(nodes ...spec.Node)  { replaceWith(c.value, nodes) }
+func (c *Comment) Remove()                         { c.value.Call("remove") }
+
+func (c *Comment) Data() string     { return c.value.Get("data").String() }
+func (c *Comment) SetData(s string) { c.value.Set("data", s) }
+
+type Attr struct {
+	value js.Value
+}
+
+func newAttr(v js.Value) spec.Attr {
+	if v.IsNull() {
+		return nil
+	}
+	return &Attr{value: v}
+}
+
+func (a *Attr) NodeType() spec.NodeType          { return nodeType(a.value) }
+func (a *Attr) CloneNode(deep bool) spec.Node    { return cloneNode(a.value, deep) }
+func (a *Attr) IsSameNode(other spec.Node) bool  { return isSameNode(a.value, other) }
+func (a *Attr) IsEqualNode(other spec.Node) bool { return isEqualNode(a.value, other) }
+func (a *Attr) TextContent() string              { return textContent(a.value) }
+
+func (a *Attr) CompareDocumentPosition(other spec.Node) spec.DocumentPosition {
+	return compareDocumentPosition(a.value, other)
+}
+
+func (a *Attr) NamespaceURI() string       { return nullableString(a.value.Get("namespaceURI")) }
+func (a *Attr) Prefix() string             { return nullableString(a.value.Get("prefix")) }
+func (a *Attr) LocalName() string          { return a.value.Get("localName").String() }
+func (a *Attr) Name() string               { return a.value.Get("name").String() }
+func (a *Attr) Value() string              { return a.value.Get("value").String() }
+func (a *Attr) SetValue(value string)      { a.value.Set("value", value) }
+func (a *Attr) OwnerElement() spec.Element { return newElement(a.value.Get("ownerElement")) }
+
+type namedNodeMap struct {
+	value js.Value
+}
+
+func (m namedNodeMap) Length() int { return m.value.Length() }
+
+func (m namedNodeMap) Item(index int) spec.Attr {
+	return newAttr(m.value.Call("item", index))
+}
+
+func (m namedNodeMap) GetNamedItem(qualifiedName string) spec.Attr {
+	return newAttr(m.value.Call("getNamedItem", qualifiedName))
+}
+
+func (m namedNodeMap) GetNamedItemNS(namespace, localName string) spec.Attr {
+	return newAttr(m.value.Call("getNamedItemNS", nullableNamespace(namespace), localName))
+}
+
+func (m namedNodeMap) SetNamedItem(attr spec.Attr) spec.Attr {
+	return newAttr(m.value.Call("setNamedItem", JSValue(attr)))
+}
+
+func (m namedNodeMap) SetNamedItemNS(attr spec.Attr) spec.Attr {
+	return newAttr(m.value.Call("setNamedItemNS", JSValue(attr)))
+}
+
+func (m namedNodeMap) RemoveNamedItem(qualifiedName string) spec.Attr {
+	return newAttr(m.value.Call("removeNamedItem", qualifiedName))
+}
+
+func (m namedNodeMap) RemoveNamedItemNS(namespace, localName string) spec.Attr {
+	return newAttr(m.value.Call("removeNamedItemNS", nullableNamespace(namespace), localName))
+}
+
+type domTokenList struct {
+	value js.Value
+}
+
+func (list domTokenList) Length() int              { return list.value.Length() }
+func (list domTokenList) Add(tokens ...string)     { list.value.Call("add", stringArray(tokens)...) }
+func (list domTokenList) Remove(tokens ...string)  { list.value.Call("remove", stringArray(tokens)...) }
+func (list domTokenList) Toggle(token string) bool { return list.value.Call("toggle", token).Bool() }
+func (list domTokenList) Value() string            { return list.value.Get("value").String() }
+func (list domTokenList) SetValue(value string)    { list.value.Set("value", value) }
+
+func (list domTokenList) Item(index int) string {
+	return nullableString(list.value.Call("item", index))
+}
+
+func (list domTokenList) Contains(token string) bool {
+	return list.value.Call("contains", token).Bool()
+}
+
+func (list domTokenList) ToggleForce(token string, force bool) bool {
+	return list.value.Call("toggle", token, force).Bool()
+}
+
+func (list domTokenList) Replace(token, newToken string) bool {
+	return list.value.Call("replace", token, newToken).Bool()
+}
+
+func (list domTokenList) Values() iter.Seq[string] {
+	return func(yield func(string) bool) {
+		for i := 0; i < list.Length(); i++ {
+			if !yield(list.Item(i)) {
+				return
+			}
+		}
+	}
+}
+
+type domStringMap struct {
+	value js.Value
+}
+
+func (m domStringMap) Get(name string) (string, bool) {
+	v := m.value.Get(name)
+	if v.IsUndefined() {
+		return "", false
+	}
+	return v.String(), true
+}
+
+func (m domStringMap) Set(name, value string) { m.value.Set(name, value) }
+func (m domStringMap) Delete(name string)     { m.value.Delete(name) }
+
+func (m domStringMap) All() iter.Seq2[string, string] {
+	return func(yield func(string, string) bool) {
+		keys := js.Global().Get("Object").Call("keys", m.value)
+		for i := 0; i < keys.Length(); i++ {
+			name := keys.Index(i).String()
+			if !yield(name, m.value.Get(name).String()) {
+				return
+			}
+		}
+	}
+}
+
+type cssStyleDeclaration struct {
+	value js.Value
+}
+
+func (s cssStyleDeclaration) CSSText() string        { return s.value.Get("cssText").String() }
+func (s cssStyleDeclaration) SetCSSText(text string) { s.value.Set("cssText", text) }
+func (s cssStyleDeclaration) Length() int            { return s.value.Length() }
+func (s cssStyleDeclaration) Item(index int) string  { return s.value.Call("item", index).String() }
+
+func (s cssStyleDeclaration) GetPropertyValue(property string) string {
+	return s.value.Call("getPropertyValue", property).String()
+}
+
+func (s cssStyleDeclaration) GetPropertyPriority(property string) string {
+	return s.value.Call("getPropertyPriority", property).String()
+}
+
+func (s cssStyleDeclaration) SetProperty(property, value, priority string) {
+	s.value.Call("setProperty", property, value, priority)
+}
+
+func (s cssStyleDeclaration) RemoveProperty(property string) string {
+	return s.value.Call("removeProperty", property).String()
+}
+
+type DocumentType struct {
+	value js.Value
+}
+
+func newDocumentType(v js.Value) spec.DocumentType {
+	if v.IsNull() {
+		return nil
+	}
+	return &DocumentType{value: v}
+}
+
+func (d *DocumentType) NodeType() spec.NodeType          { return nodeType(d.value) }
+func (d *DocumentType) CloneNode(deep bool) spec.Node    { return cloneNode(d.value, deep) }
+func (d *DocumentType) IsSameNode(other spec.Node) bool  { return isSameNode(d.value, other) }
+func (d *DocumentType) IsEqualNode(other spec.Node) bool { return isEqualNode(d.value, other) }
+func (d *DocumentType) TextContent() string              { return "" }
+func (d *DocumentType) Length() int                      { return 0 }
+
+func (d *DocumentType) LookupPrefix(namespace string) string {
+	return lookupPrefix(d.value, namespace)
+}
+
+func (d *DocumentType) LookupNamespaceURI(prefix string) string {
+	return lookupNamespaceURI(d.value, prefix)
+}
+
+func (d *DocumentType) IsDefaultNamespace(namespace string) bool {
+	return isDefaultNamespace(d.value, namespace)
+}
+
+func (d *DocumentType) CompareDocumentPosition(other spec.Node) spec.DocumentPosition {
+	return compareDocumentPosition(d.value, other)
+}
+
+func (d *DocumentType) IsConnected() bool               { return isConnected(d.value) }
+func (d *DocumentType) OwnerDocument() spec.Document    { return ownerDocument(d.value) }
+func (d *DocumentType) ParentNode() spec.Node           { return parentNode(d.value) }
+func (d *DocumentType) ParentElement() spec.Element     { return parentElement(d.value) }
+func (d *DocumentType) PreviousSibling() spec.ChildNode { return previousSibling(d.value) }
+func (d *DocumentType) NextSibling() spec.ChildNode     { return nextSibling(d.value) }
+func (d *DocumentType) Before(nodes ...spec.Node)       { before(d.value, nodes) }
+func (d *DocumentType) After(nodes ...spec.Node)        { after(d.value, nodes) }
+func (d *DocumentType) ReplaceWith(nodes ...spec.Node)  { replaceWith(d.value, nodes) }
+func (d *DocumentType) Remove()                         { d.value.Call("remove") }
+
+func (d *DocumentType) Name() string     { return d.value.Get("name").String() }
+func (d *DocumentType) PublicID() string { return d.value.Get("publicId").String() }
+func (d *DocumentType) SystemID() string { return d.value.Get("systemId").String() }
+
 var (
 	nodeClass             = js.Global().Get("Node")
 	textClass             = js.Global().Get("Text")
+	commentClass          = js.Global().Get("Comment")
+	documentTypeClass     = js.Global().Get("DocumentType")
+	attrClass             = js.Global().Get("Attr")
 	documentClass         = js.Global().Get("Document")
 	documentFragmentClass = js.Global().Get("DocumentFragment")
 	elementClass          = js.Global().Get("Element")
@@ -277,6 +741,15 @@ func NewNode(value js.Value) spec.Node {
 	if value.InstanceOf(textClass) {
 		return newTextNode(value)
 	}
+	if value.InstanceOf(commentClass) {
+		return newComment(value)
+	}
+	if value.InstanceOf(documentTypeClass) {
+		return newDocumentType(value)
+	}
+	if value.InstanceOf(attrClass) {
+		return newAttr(value)
+	}
 	if value.InstanceOf(documentClass) {
 		return newDocument(value)
 	}
@@ -309,6 +782,20 @@ func JSValue(node any) js.Value {
 		return n.value
 	case *Text:
 		return n.value
+	case *Comment:
+		return n.value
+	case *DocumentType:
+		return n.value
+	case *Attr:
+		return n.value
+	case *Range:
+		return n.value
+	case *StaticRange:
+		return n.value
+	case *Event:
+		return n.value
+	case *EventTarget:
+		return n.value
 	case js.Value:
 		return n
 	default:
@@ -316,6 +803,14 @@ func JSValue(node any) js.Value {
 	}
 }
 
+func stringArray(in []string) []any {
+	out := make([]any, 0, len(in))
+	for _, s := range in {
+		out = append(out, s)
+	}
+	return out
+}
+
 func valueArray(in []spec.Node) []any {
 	out := make([]any, 0, len(in))
 	for _, n := range in {
@@ -324,6 +819,22 @@ func valueArray(in []spec.Node) []any {
 	return out
 }
 
+// nullableString returns an empty string for null and undefined values.
+func nullableString(value js.Value) string {
+	if value.IsNull() || value.IsUndefined() {
+		return ""
+	}
+	return value.String()
+}
+
+// nullableNamespace converts the empty string to the null namespace.
+func nullableNamespace(namespace string) any {
+	if namespace == "" {
+		return nil
+	}
+	return namespace
+}
+
 func nodeType(receiver js.Value) spec.NodeType {
 	return spec.NodeType(receiver.Get("nodeType").Int())
 }
@@ -336,6 +847,22 @@ func isSameNode(receiver js.Value, other spec.Node) bool {
 	return receiver.Call("isSameNode", JSValue(other)).Bool()
 }
 
+func isEqualNode(receiver js.Value, other spec.Node) bool {
+	return receiver.Call("isEqualNode", JSValue(other)).Bool()
+}
+
+func lookupPrefix(receiver js.Value, namespace string) string {
+	return nullableString(receiver.Call("lookupPrefix", nullableNamespace(namespace)))
+}
+
+func lookupNamespaceURI(receiver js.Value, prefix string) string {
+	return nullableString(receiver.Call("lookupNamespaceURI", nullableNamespace(prefix)))
+}
+
+func isDefaultNamespace(receiver js.Value, namespace string) bool {
+	return receiver.Call("isDefaultNamespace", nullableNamespace(namespace)).Bool()
+}
+
 func textContent(receiver js.Value) string {
 	return receiver.Get("textContent").String()
 }
@@ -366,6 +893,10 @@ func getElementsByTagName(receiver js.Value, name string) spec.ElementCollection
 	return htmlCollection{value: receiver.Call("getElementsByTagName", name)}
 }
 
+func getElementsByTagNameNS(receiver js.Value, namespace, localName string) spec.ElementCollection {
+	return htmlCollection{value: receiver.Call("getElementsByTagNameNS", nullableNamespace(namespace), localName)}
+}
+
 func getElementsByClassName(receiver js.Value, name string) spec.ElementCollection {
 	return htmlCollection{value: receiver.Call("getElementsByClassName", name)}
 }
@@ -417,6 +948,10 @@ func createTextNode(receiver js.Value, text string) spec.Text {
 	return &Text{value: n}
 }
 
+func createComment(receiver js.Value, data string) spec.Comment {
+	return newComment(receiver.Call("createComment", data))
+}
+
 func isConnected(receiver js.Value) bool { return receiver.Get("isConnected").Bool() }
 func ownerDocument(receiver js.Value) spec.Document {
 	return newDocument(receiver.Get("ownerDocument"))
@@ -460,9 +995,409 @@ func replaceChildrenNodes(receiver js.Value, in []spec.Node) {
 	receiver.Call("replaceChildren", valueArray(in)...)
 }
 
+func before(receiver js.Value, in []spec.Node) {
+	receiver.Call("before", valueArray(in)...)
+}
+
+func after(receiver js.Value, in []spec.Node) {
+	receiver.Call("after", valueArray(in)...)
+}
+
+func replaceWith(receiver js.Value, in []spec.Node) {
+	receiver.Call("replaceWith", valueArray(in)...)
+}
+
 type nodeList struct {
 	value js.Value
 }
 
 func (n nodeList) Length() int          { return n.value.Length() }
 func (n nodeList) Item(i int) spec.Node { return NewNode(n.value.Call("item", i)) }
+
+// nodeFilter wraps filter in a js.Func. The function is not released because the
+// traversal objects that use it do not have a lifetime that ends explicitly.
+func nodeFilter(filter spec.NodeFilter) any {
+	if filter == nil {
+		return nil
+	}
+	return js.FuncOf(func(_ js.Value, args []js.Value) any {
+		return int(filter.AcceptNode(NewNode(args[0])))
+	})
+}
+
+type TreeWalker struct {
+	value  js.Value
+	filter spec.NodeFilter
+}
+
+func (w *TreeWalker) Root() spec.Node             { return NewNode(w.value.Get("root")) }
+func (w *TreeWalker) WhatToShow() spec.WhatToShow { return whatToShow(w.value) }
+func (w *TreeWalker) Filter() spec.NodeFilter     { return w.filter }
+func (w *TreeWalker) CurrentNode() spec.Node      { return NewNode(w.value.Get("currentNode")) }
+func (w *TreeWalker) SetCurrentNode(node spec.Node) {
+	w.value.Set("currentNode", JSValue(node))
+}
+
+func (w *TreeWalker) ParentNode() spec.Node      { return NewNode(w.value.Call("parentNode")) }
+func (w *TreeWalker) FirstChild() spec.Node      { return NewNode(w.value.Call("firstChild")) }
+func (w *TreeWalker) LastChild() spec.Node       { return NewNode(w.value.Call("lastChild")) }
+func (w *TreeWalker) PreviousSibling() spec.Node { return NewNode(w.value.Call("previousSibling")) }
+func (w *TreeWalker) NextSibling() spec.Node     { return NewNode(w.value.Call("nextSibling")) }
+func (w *TreeWalker) PreviousNode() spec.Node    { return NewNode(w.value.Call("previousNode")) }
+func (w *TreeWalker) NextNode() spec.Node        { return NewNode(w.value.Call("nextNode")) }
+
+type NodeIterator struct {
+	value  js.Value
+	filter spec.NodeFilter
+}
+
+func (it *NodeIterator) Root() spec.Node          { return NewNode(it.value.Get("root")) }
+func (it *NodeIterator) ReferenceNode() spec.Node { return NewNode(it.value.Get("referenceNode")) }
+func (it *NodeIterator) PointerBeforeReferenceNode() bool {
+	return it.value.Get("pointerBeforeReferenceNode").Bool()
+}
+
+func (it *NodeIterator) WhatToShow() spec.WhatToShow { return whatToShow(it.value) }
+func (it *NodeIterator) Filter() spec.NodeFilter     { return it.filter }
+func (it *NodeIterator) NextNode() spec.Node         { return NewNode(it.value.Call("nextNode")) }
+func (it *NodeIterator) PreviousNode() spec.Node     { return NewNode(it.value.Call("previousNode")) }
+
+type MutationObserver struct {
+	value js.Value
+}
+
+// NewMutationObserver wraps callback in a js.Func. The function is not released because
+// an observer may observe again after Disconnect. The callback may be nil.
+func NewMutationObserver(callback spec.MutationCallback) spec.MutationObserver {
+	observer := new(MutationObserver)
+	fn := js.FuncOf(func(_ js.Value, args []js.Value) any {
+		if callback != nil {
+			callback(mutationRecords(args[0]), observer)
+		}
+		return nil
+	})
+	observer.value = js.Global().Get("MutationObserver").New(fn)
+	return observer
+}
+
+func (o *MutationObserver) Observe(target spec.Node, options spec.MutationObserverInit) {
+	init := make(map[string]any)
+	for key, value := range map[string]bool{
+		"childList":             options.ChildList,
+		"attributes":            options.Attributes,
+		"characterData":         options.CharacterData,
+		"subtree":               options.Subtree,
+		"attributeOldValue":     options.AttributeOldValue,
+		"characterDataOldValue": options.CharacterDataOldValue,
+	} {
+		if value {
+			init[key] = true
+		}
+	}
+	if options.AttributeFilter != nil {
+		init["attributeFilter"] = stringArray(options.AttributeFilter)
+	}
+	o.value.Call("observe", JSValue(target), init)
+}
+
+func (o *MutationObserver) Disconnect() { o.value.Call("disconnect") }
+
+func (o *MutationObserver) TakeRecords() []spec.MutationRecord {
+	return mutationRecords(o.value.Call("takeRecords"))
+}
+
+func mutationRecords(array js.Value) []spec.MutationRecord {
+	records := make([]spec.MutationRecord, array.Length())
+	for i := range records {
+		records[i] = &MutationRecord{value: array.Index(i)}
+	}
+	return records
+}
+
+type MutationRecord struct {
+	value js.Value
+}
+
+func (r *MutationRecord) Type() spec.MutationRecordType {
+	return spec.MutationRecordType(r.value.Get("type").String())
+}
+
+func (r *MutationRecord) AddedNodes() spec.NodeList[spec.Node] {
+	return nodeList{value: r.value.Get("addedNodes")}
+}
+
+func (r *MutationRecord) RemovedNodes() spec.NodeList[spec.Node] {
+	return nodeList{value: r.value.Get("removedNodes")}
+}
+
+func (r *MutationRecord) AttributeNamespace() string {
+	return nullableString(r.value.Get("attributeNamespace"))
+}
+
+func (r *MutationRecord) Target() spec.Node          { return NewNode(r.value.Get("target")) }
+func (r *MutationRecord) PreviousSibling() spec.Node { return NewNode(r.value.Get("previousSibling")) }
+func (r *MutationRecord) NextSibling() spec.Node     { return NewNode(r.value.Get("nextSibling")) }
+func (r *MutationRecord) AttributeName() string      { return nullableString(r.value.Get("attributeName")) }
+func (r *MutationRecord) OldValue() string           { return nullableString(r.value.Get("oldValue")) }
+
+type StaticRange struct {
+	value js.Value
+}
+
+// NewStaticRange calls the StaticRange constructor.
+func NewStaticRange(startContainer spec.Node, startOffset int, endContainer spec.Node, endOffset int) spec.StaticRange {
+	return &StaticRange{value: js.Global().Get("StaticRange").New(map[string]any{
+		"startContainer": JSValue(startContainer),
+		"startOffset":    startOffset,
+		"endContainer":   JSValue(endContainer),
+		"endOffset":      endOffset,
+	})}
+}
+
+func (r *StaticRange) StartContainer() spec.Node { return NewNode(r.value.Get("startContainer")) }
+func (r *StaticRange) StartOffset() int          { return r.value.Get("startOffset").Int() }
+func (r *StaticRange) EndContainer() spec.Node   { return NewNode(r.value.Get("endContainer")) }
+func (r *StaticRange) EndOffset() int            { return r.value.Get("endOffset").Int() }
+func (r *StaticRange) Collapsed() bool           { return r.value.Get("collapsed").Bool() }
+
+type Range struct {
+	value js.Value
+}
+
+func (r *Range) StartContainer() spec.Node { return NewNode(r.value.Get("startContainer")) }
+func (r *Range) StartOffset() int          { return r.value.Get("startOffset").Int() }
+func (r *Range) EndContainer() spec.Node   { return NewNode(r.value.Get("endContainer")) }
+func (r *Range) EndOffset() int            { return r.value.Get("endOffset").Int() }
+func (r *Range) Collapsed() bool           { return r.value.Get("collapsed").Bool() }
+
+func (r *Range) CommonAncestorContainer() spec.Node {
+	return NewNode(r.value.Get("commonAncestorContainer"))
+}
+
+func (r *Range) SetStart(node spec.Node, offset int) { r.value.Call("setStart", JSValue(node), offset) }
+func (r *Range) SetEnd(node spec.Node, offset int)   { r.value.Call("setEnd", JSValue(node), offset) }
+func (r *Range) SetStartBefore(node spec.Node)       { r.value.Call("setStartBefore", JSValue(node)) }
+func (r *Range) SetStartAfter(node spec.Node)        { r.value.Call("setStartAfter", JSValue(node)) }
+func (r *Range) SetEndBefore(node spec.Node)         { r.value.Call("setEndBefore", JSValue(node)) }
+func (r *Range) SetEndAfter(node spec.Node)          { r.value.Call("setEndAfter", JSValue(node)) }
+func (r *Range) Collapse(toStart bool)               { r.value.Call("collapse", toStart) }
+func (r *Range) SelectNode(node spec.Node)           { r.value.Call("selectNode", JSValue(node)) }
+func (r *Range) SelectNodeContents(node spec.Node)   { r.value.Call("selectNodeContents", JSValue(node)) }
+
+func (r *Range) CompareBoundaryPoints(how spec.RangeCompare, sourceRange spec.Range) int {
+	return r.value.Call("compareBoundaryPoints", int(how), JSValue(sourceRange)).Int()
+}
+
+func (r *Range) DeleteContents() { r.value.Call("deleteContents") }
+
+func (r *Range) ExtractContents() spec.DocumentFragment {
+	return &DocumentFragment{value: r.value.Call("extractContents")}
+}
+
+func (r *Range) CloneContents() spec.DocumentFragment {
+	return &DocumentFragment{value: r.value.Call("cloneContents")}
+}
+
+func (r *Range) InsertNode(node spec.Node)         { r.value.Call("insertNode", JSValue(node)) }
+func (r *Range) SurroundContents(parent spec.Node) { r.value.Call("surroundContents", JSValue(parent)) }
+func (r *Range) CloneRange() spec.Range            { return &Range{value: r.value.Call("cloneRange")} }
+
+func (r *Range) IsPointInRange(node spec.Node, offset int) bool {
+	return r.value.Call("isPointInRange", JSValue(node), offset).Bool()
+}
+
+func (r *Range) ComparePoint(node spec.Node, offset int) int {
+	return r.value.Call("comparePoint", JSValue(node), offset).Int()
+}
+
+func (r *Range) IntersectsNode(node spec.Node) bool {
+	return r.value.Call("intersectsNode", JSValue(node)).Bool()
+}
+
+func (r *Range) String() string { return r.value.Call("toString").String() }
+
+func whatToShow(traversal js.Value) spec.WhatToShow {
+	return spec.WhatToShow(traversal.Get("whatToShow").Int())
+}
+
+// EventTarget wraps event targets that are not nodes.
+type EventTarget struct {
+	value js.Value
+}
+
+func newEventTarget(value js.Value) spec.EventTarget {
+	if value.IsNull() || value.IsUndefined() {
+		return nil
+	}
+	if target, ok := NewNode(value).(spec.EventTarget); ok {
+		return target
+	}
+	return &EventTarget{value: value}
+}
+
+func (t *EventTarget) AddEventListener(eventType string, listener spec.EventListener, options spec.AddEventListenerOptions) {
+	addEventListener(t.value, eventType, listener, options)
+}
+
+func (t *EventTarget) RemoveEventListener(eventType string, listener spec.EventListener, options spec.EventListenerOptions) {
+	removeEventListener(t.value, eventType, listener, options)
+}
+
+func (t *EventTarget) DispatchEvent(event spec.Event) bool { return dispatchEvent(t.value, event) }
+
+func (d *Document) AddEventListener(eventType string, listener spec.EventListener, options spec.AddEventListenerOptions) {
+	addEventListener(d.value, eventType, listener, options)
+}
+
+func (d *Document) RemoveEventListener(eventType string, listener spec.EventListener, options spec.EventListenerOptions) {
+	removeEventListener(d.value, eventType, listener, options)
+}
+
+func (d *Document) DispatchEvent(event spec.Event) bool { return dispatchEvent(d.value, event) }
+
+func (d *DocumentFragment) AddEventListener(eventType string, listener spec.EventListener, options spec.AddEventListenerOptions) {
+	addEventListener(d.value, eventType, listener, options)
+}
+
+func (d *DocumentFragment) RemoveEventListener(eventType string, listener spec.EventListener, options spec.EventListenerOptions) {
+	removeEventListener(d.value, eventType, listener, options)
+}
+
+func (d *DocumentFragment) DispatchEvent(event spec.Event) bool { return dispatchEvent(d.value, event) }
+
+func (e *Element) AddEventListener(eventType string, listener spec.EventListener, options spec.AddEventListenerOptions) {
+	addEventListener(e.value, eventType, listener, options)
+}
+
+func (e *Element) RemoveEventListener(eventType string, listener spec.EventListener, options spec.EventListenerOptions) {
+	removeEventListener(e.value, eventType, listener, options)
+}
+
+func (e *Element) DispatchEvent(event spec.Event) bool { return dispatchEvent(e.value, event) }
+
+func (t *Text) AddEventListener(eventType string, listener spec.EventListener, options spec.AddEventListenerOptions) {
+	addEventListener(t.value, eventType, listener, options)
+}
+
+func (t *Text) RemoveEventListener(eventType string, listener spec.EventListener, options spec.EventListenerOptions) {
+	removeEventListener(t.value, eventType, listener, options)
+}
+
+func (t *Text) DispatchEvent(event spec.Event) bool { return dispatchEvent(t.value, event) }
+
+func (c *Comment) AddEventListener(eventType string, listener spec.EventListener, options spec.AddEventListenerOptions) {
+	addEventListener(c.value, eventType, listener, options)
+}
+
+func (c *Comment) RemoveEventListener(eventType string, listener spec.EventListener, options spec.EventListenerOptions) {
+	removeEventListener(c.value, eventType, listener, options)
+}
+
+func (c *Comment) DispatchEvent(event spec.Event) bool { return dispatchEvent(c.value, event) }
+
+func (d *DocumentType) AddEventListener(eventType string, listener spec.EventListener, options spec.AddEventListenerOptions) {
+	addEventListener(d.value, eventType, listener, options)
+}
+
+func (d *DocumentType) RemoveEventListener(eventType string, listener spec.EventListener, options spec.EventListenerOptions) {
+	removeEventListener(d.value, eventType, listener, options)
+}
+
+func (d *DocumentType) DispatchEvent(event spec.Event) bool { return dispatchEvent(d.value, event) }
+
+// eventListenerFunc is a listener added to target. JavaScript compares listeners by
+// identity, so the js.Func for a listener is kept until it is removed.
+type eventListenerFunc struct {
+	target    js.Value
+	eventType string
+	listener  spec.EventListener
+	capture   bool
+	fn        js.Func
+}
+
+var eventListenerFuncs []*eventListenerFunc
+
+func findEventListenerFunc(target js.Value, eventType string, listener spec.EventListener, capture bool) int {
+	return slices.IndexFunc(eventListenerFuncs, func(l *eventListenerFunc) bool {
+		return l.target.Equal(target) && l.eventType == eventType && l.capture == capture && sameEventListener(l.listener, listener)
+	})
+}
+
+// sameEventListener compares listeners with == when their type is comparable.
+func sameEventListener(a, b spec.EventListener) bool {
+	t := reflect.TypeOf(a)
+	return t == reflect.TypeOf(b) && t != nil && t.Comparable() && a == b
+}
+
+func addEventListener(receiver js.Value, eventType string, listener spec.EventListener, options spec.AddEventListenerOptions) {
+	if listener == nil || findEventListenerFunc(receiver, eventType, listener, options.Capture) >= 0 {
+		return
+	}
+	l := &eventListenerFunc{target: receiver, eventType: eventType, listener: listener, capture: options.Capture}
+	l.fn = js.FuncOf(func(_ js.Value, args []js.Value) any {
+		if options.Once {
+			releaseEventListenerFunc(l)
+		}
+		listener.HandleEvent(&Event{value: args[0]})
+		return nil
+	})
+	eventListenerFuncs = append(eventListenerFuncs, l)
+	receiver.Call("addEventListener", eventType, l.fn, map[string]any{
+		"capture": options.Capture,
+		"once":    options.Once,
+		"passive": options.Passive,
+	})
+}
+
+func removeEventListener(receiver js.Value, eventType string, listener spec.EventListener, options spec.EventListenerOptions) {
+	i := findEventListenerFunc(receiver, eventType, listener, options.Capture)
+	if i < 0 {
+		return
+	}
+	l := eventListenerFuncs[i]
+	receiver.Call("removeEventListener", eventType, l.fn, map[string]any{"capture": options.Capture})
+	releaseEventListenerFunc(l)
+}
+
+func releaseEventListenerFunc(l *eventListenerFunc) {
+	eventListenerFuncs = slices.DeleteFunc(eventListenerFuncs, func(other *eventListenerFunc) bool { return other == l })
+	l.fn.Release()
+}
+
+func dispatchEvent(receiver js.Value, event spec.Event) bool {
+	return receiver.Call("dispatchEvent", JSValue(event)).Bool()
+}
+
+type Event struct {
+	value js.Value
+}
+
+func NewEvent(eventType string, init spec.EventInit) spec.Event {
+	return &Event{value: js.Global().Get("Event").New(eventType, map[string]any{
+		"bubbles":    init.Bubbles,
+		"cancelable": init.Cancelable,
+		"composed":   init.Composed,
+	})}
+}
+
+func (e *Event) Type() string                    { return e.value.Get("type").String() }
+func (e *Event) Target() spec.EventTarget        { return newEventTarget(e.value.Get("target")) }
+func (e *Event) CurrentTarget() spec.EventTarget { return newEventTarget(e.value.Get("currentTarget")) }
+func (e *Event) EventPhase() spec.EventPhase     { return spec.EventPhase(e.value.Get("eventPhase").Int()) }
+func (e *Event) StopPropagation()                { e.value.Call("stopPropagation") }
+func (e *Event) StopImmediatePropagation()       { e.value.Call("stopImmediatePropagation") }
+func (e *Event) Bubbles() bool                   { return e.value.Get("bubbles").Bool() }
+func (e *Event) Cancelable() bool                { return e.value.Get("cancelable").Bool() }
+func (e *Event) Composed() bool                  { return e.value.Get("composed").Bool() }
+func (e *Event) PreventDefault()                 { e.value.Call("preventDefault") }
+func (e *Event) DefaultPrevented() bool          { return e.value.Get("defaultPrevented").Bool() }
+func (e *Event) IsTrusted() bool                 { return e.value.Get("isTrusted").Bool() }
+
+func (e *Event) ComposedPath() []spec.EventTarget {
+	array := e.value.Call("composedPath")
+	path := make([]spec.EventTarget, array.Length())
+	for i := range path {
+		path[i] = newEventTarget(array.Index(i))
+	}
+	return path
+}
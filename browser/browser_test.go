@@ -55,6 +55,10 @@ func TestDocument(t *testing.T) {
 	t.Run("GetElementsByTagName", func(t *testing.T) {
 		assert.NotZero(t, document.GetElementsByTagName("div").Length())
 	})
+	t.Run("GetElementsByTagNameNS", func(t *testing.T) {
+		assert.NotZero(t, document.GetElementsByTagNameNS(spec.NamespaceHTML, "div").Length())
+		assert.Zero(t, document.GetElementsByTagNameNS(spec.NamespaceSVG, "div").Length())
+	})
 	t.Run("GetElementsByClassName", func(t *testing.T) {
 		assert.Equal(t, 2, document.GetElementsByClassName(childClass).Length())
 	})
@@ -108,3 +112,449 @@ func TestElement_CompareDocumentPosition(t *testing.T) {
 		assert.True(t, spec.DocumentPositionImplementationSpecific&pos != 0)
 	})
 }
+
+func TestDocument_CreateComment(t *testing.T) {
+	document := browser.OpenDocument()
+
+	comment := document.CreateComment("marker")
+	require.NotNil(t, comment)
+	assert.Equal(t, spec.NodeTypeComment, comment.NodeType())
+	assert.Equal(t, "marker", comment.Data())
+
+	div := document.CreateElement("div")
+	div.AppendChild(comment)
+	assert.True(t, div.FirstChild().IsSameNode(comment))
+
+	comment.SetData("changed")
+	assert.Equal(t, "<!--changed-->", div.InnerHTML())
+}
+
+func TestDocument_Doctype(t *testing.T) {
+	document := browser.OpenDocument()
+
+	doctype := document.Doctype()
+	if doctype == nil {
+		t.Skip("test page does not declare a doctype")
+	}
+	assert.Equal(t, spec.NodeTypeDocumentType, doctype.NodeType())
+	assert.Equal(t, "html", doctype.Name())
+}
+
+func TestElement_Attributes(t *testing.T) {
+	document := browser.OpenDocument()
+
+	el := document.CreateElement("div")
+	el.SetAttribute("id", "a")
+	el.SetAttribute("class", "x")
+
+	attributes := el.Attributes()
+	require.Equal(t, 2, attributes.Length())
+	assert.Equal(t, "id", attributes.Item(0).Name())
+	assert.Equal(t, "class", attributes.Item(1).Name())
+
+	attr := el.GetAttributeNode("class")
+	require.NotNil(t, attr)
+	assert.Equal(t, "x", attr.Value())
+	assert.True(t, attr.OwnerElement().IsSameNode(el))
+
+	removed := el.RemoveAttributeNode(attr)
+	assert.Nil(t, removed.OwnerElement())
+	assert.False(t, el.HasAttribute("class"))
+
+	assert.Nil(t, el.SetAttributeNode(removed))
+	assert.Equal(t, "x", el.GetAttribute("class"))
+}
+
+func TestElement_GetAttributeNS(t *testing.T) {
+	document := browser.OpenDocument()
+
+	div := document.CreateElement("div")
+	div.SetInnerHTML(`<svg><use id="icon" xlink:href="#shape" href="#plain"/></svg>`)
+	icon := div.QuerySelector("#icon")
+	require.NotNil(t, icon)
+
+	assert.Equal(t, "#shape", icon.GetAttributeNS(spec.NamespaceXLink, "href"))
+	assert.Equal(t, "#plain", icon.GetAttributeNS("", "href"))
+	assert.Zero(t, icon.GetAttributeNS(spec.NamespaceXML, "lang"))
+
+	icon.SetAttributeNS(spec.NamespaceXML, "xml:lang", "en-US")
+	assert.True(t, icon.HasAttributeNS(spec.NamespaceXML, "lang"))
+
+	icon.RemoveAttributeNS(spec.NamespaceXLink, "href")
+	assert.False(t, icon.HasAttributeNS(spec.NamespaceXLink, "href"))
+}
+
+func TestDocument_CreateElementNS(t *testing.T) {
+	document := browser.OpenDocument()
+
+	gradient := document.CreateElementNS(spec.NamespaceSVG, "linearGradient")
+	require.NotNil(t, gradient)
+	assert.Equal(t, "linearGradient", gradient.TagName())
+	assert.Equal(t, "linearGradient", gradient.LocalName())
+	assert.Equal(t, spec.NamespaceSVG, gradient.NamespaceURI())
+	assert.Zero(t, gradient.Prefix())
+
+	div := document.CreateElement("div")
+	assert.Equal(t, "DIV", div.TagName())
+	assert.Equal(t, spec.NamespaceHTML, div.NamespaceURI())
+}
+
+func TestElement_ClassList(t *testing.T) {
+	document := browser.OpenDocument()
+
+	el := document.CreateElement("div")
+	el.SetAttribute("class", "a b")
+
+	list := el.ClassList()
+	assert.Equal(t, 2, list.Length())
+	assert.Equal(t, "a", list.Item(0))
+	assert.Zero(t, list.Item(5))
+
+	list.Add("c")
+	assert.True(t, list.Contains("c"))
+	assert.False(t, list.ToggleForce("a", false))
+	assert.True(t, list.Replace("b", "d"))
+	assert.Equal(t, "d c", el.ClassName())
+
+	var tokens []string
+	for token := range list.Values() {
+		tokens = append(tokens, token)
+	}
+	assert.Equal(t, []string{"d", "c"}, tokens)
+}
+
+func TestElement_Dataset(t *testing.T) {
+	document := browser.OpenDocument()
+
+	el := document.CreateElement("p")
+	el.SetAttribute("data-foo-bar", "1")
+
+	dataset := el.Dataset()
+	value, ok := dataset.Get("fooBar")
+	assert.True(t, ok)
+	assert.Equal(t, "1", value)
+
+	_, ok = dataset.Get("missing")
+	assert.False(t, ok)
+
+	dataset.Set("htmxTarget", "#main")
+	assert.Equal(t, "#main", el.GetAttribute("data-htmx-target"))
+
+	dataset.Delete("fooBar")
+	assert.False(t, el.HasAttribute("data-foo-bar"))
+
+	var names []string
+	for name := range dataset.All() {
+		names = append(names, name)
+	}
+	assert.Equal(t, []string{"htmxTarget"}, names)
+}
+
+func TestElement_Style(t *testing.T) {
+	document := browser.OpenDocument()
+
+	el := document.CreateElement("p")
+	el.SetAttribute("style", "color: darkred;")
+
+	style := el.Style()
+	assert.Equal(t, 1, style.Length())
+	assert.Equal(t, "color", style.Item(0))
+	assert.Equal(t, "darkred", style.GetPropertyValue("color"))
+
+	style.SetProperty("margin", "0px", "important")
+	assert.Equal(t, "important", style.GetPropertyPriority("margin"))
+	assert.Equal(t, "darkred", style.RemoveProperty("color"))
+	assert.Equal(t, "margin: 0px !important;", style.CSSText())
+}
+
+func TestElement_ChildNode(t *testing.T) {
+	document := browser.OpenDocument()
+
+	list := document.CreateElement("ul")
+	list.SetInnerHTML(`<li id="a">a</li><li id="b">b</li>`)
+	a := list.QuerySelector("#a")
+	b := list.QuerySelector("#b")
+
+	b.Before(document.CreateTextNode("1"))
+	b.After(document.CreateComment("2"))
+	a.ReplaceWith(document.CreateElement("br"))
+	assert.Equal(t, `<br>1<li id="b">b</li><!--2-->`, list.InnerHTML())
+
+	b.Remove()
+	assert.Equal(t, `<br>1<!--2-->`, list.InnerHTML())
+}
+
+func TestElement_InsertAdjacent(t *testing.T) {
+	document := browser.OpenDocument()
+
+	parent := document.CreateElement("div")
+	parent.SetInnerHTML(`<p id="target"></p>`)
+	target := parent.QuerySelector("#target")
+
+	target.InsertAdjacentHTML("beforebegin", `<b>1</b>`)
+	target.InsertAdjacentText("afterbegin", "2")
+	el := document.CreateElement("i")
+	assert.True(t, el.IsSameNode(target.InsertAdjacentElement("afterend", el)))
+	assert.Equal(t, `<b>1</b><p id="target">2</p><i></i>`, parent.InnerHTML())
+}
+
+func TestDocument_GetElementById(t *testing.T) {
+	document := browser.OpenDocument()
+
+	el := document.CreateElement("div")
+	el.SetAttribute("id", "post.title:1")
+	document.Body().Append(el)
+	t.Cleanup(el.Remove)
+
+	assert.True(t, el.IsSameNode(document.GetElementById("post.title:1")))
+	assert.Nil(t, document.GetElementById("missing"))
+}
+
+func TestDocument_Factories(t *testing.T) {
+	document := browser.OpenDocument()
+
+	fragment := document.CreateDocumentFragment()
+	fragment.Append(document.CreateElement("p"))
+	assert.Equal(t, 1, fragment.ChildElementCount())
+
+	attr := document.CreateAttribute("data-fruit")
+	attr.SetValue("peach")
+	assert.Equal(t, "data-fruit", attr.Name())
+
+	list := document.CreateElement("ul")
+	list.SetInnerHTML(`<li>peach</li>`)
+	imported := document.ImportNode(list, true).(spec.Element)
+	assert.False(t, imported.IsSameNode(list))
+	assert.Equal(t, list.OuterHTML(), imported.OuterHTML())
+
+	item := list.FirstElementChild()
+	assert.True(t, document.AdoptNode(item).IsSameNode(item))
+	assert.Nil(t, item.ParentNode())
+}
+
+func TestDocument_Title(t *testing.T) {
+	document := browser.OpenDocument()
+
+	assert.Equal(t, "HTML", document.DocumentElement().TagName())
+
+	previous := document.Title()
+	t.Cleanup(func() { document.SetTitle(previous) })
+	document.SetTitle("  Peach \n Pie ")
+	assert.Equal(t, "Peach Pie", document.Title())
+}
+
+func TestDocument_Forms(t *testing.T) {
+	document := browser.OpenDocument()
+
+	before := document.Forms().Length()
+	form := document.CreateElement("form")
+	document.Body().Append(form)
+	t.Cleanup(form.Remove)
+
+	assert.Equal(t, before+1, document.Forms().Length())
+}
+
+func TestNode_IsEqualNode(t *testing.T) {
+	document := browser.OpenDocument()
+
+	a := document.CreateElement("div")
+	a.SetInnerHTML(`<p class="x" id="y">1</p>`)
+	b := document.CreateElement("div")
+	b.SetInnerHTML(`<p id="y" class="x">1</p>`)
+	assert.True(t, a.IsEqualNode(b))
+
+	b.FirstElementChild().SetAttribute("class", "z")
+	assert.False(t, a.IsEqualNode(b))
+}
+
+func TestElement_Normalize(t *testing.T) {
+	document := browser.OpenDocument()
+
+	el := document.CreateElement("div")
+	el.Append(document.CreateTextNode("a"), document.CreateTextNode(""), document.CreateTextNode("b"))
+	el.(spec.Normalizer).Normalize()
+
+	assert.Equal(t, 1, el.ChildNodes().Length())
+	assert.Equal(t, "ab", el.TextContent())
+}
+
+func TestText_CharacterData(t *testing.T) {
+	document := browser.OpenDocument()
+
+	el := document.CreateElement("p")
+	text := document.CreateTextNode("🍑pie")
+	el.Append(text)
+	assert.Equal(t, 5, text.Length())
+
+	text.InsertData(2, " ")
+	assert.Equal(t, "🍑 pie", text.Data())
+	assert.Equal(t, "🍑", text.SubstringData(0, 2))
+	text.ReplaceData(3, 3, "tart")
+	text.AppendData("!")
+	text.DeleteData(0, 3)
+	assert.Equal(t, "tart!", text.Data())
+
+	rest := text.SplitText(4)
+	assert.Equal(t, "tart", text.Data())
+	assert.Equal(t, "!", rest.Data())
+	assert.Equal(t, "tart!", rest.WholeText())
+}
+
+func TestNode_LookupNamespaceURI(t *testing.T) {
+	document := browser.OpenDocument()
+
+	el := document.CreateElementNS("urn:example", "ex:item")
+	child := document.CreateElementNS("urn:child", "child")
+	el.Append(child)
+
+	assert.Equal(t, "urn:example", child.LookupNamespaceURI("ex"))
+	assert.Equal(t, "ex", child.LookupPrefix("urn:example"))
+	assert.True(t, child.IsDefaultNamespace("urn:child"))
+	assert.Equal(t, "", child.LookupNamespaceURI("missing"))
+}
+
+func TestDocument_CreateTreeWalker(t *testing.T) {
+	document := browser.OpenDocument()
+
+	root := document.CreateElement("div")
+	root.SetInnerHTML(`<p>one</p><!--note--><p class="skip">two</p>`)
+	filter := spec.NodeFilterFunc(func(node spec.Node) spec.FilterResult {
+		if el, ok := node.(spec.Element); ok && el.ClassName() == "skip" {
+			return spec.FilterReject
+		}
+		return spec.FilterAccept
+	})
+
+	walker := document.CreateTreeWalker(root, spec.ShowElement|spec.ShowComment, filter)
+	assert.Equal(t, spec.NodeTypeElement, walker.NextNode().NodeType())
+	assert.Equal(t, spec.NodeTypeComment, walker.NextNode().NodeType())
+	assert.Nil(t, walker.NextNode())
+
+	iterator := document.CreateNodeIterator(root, spec.ShowText, nil)
+	first := iterator.NextNode()
+	assert.Equal(t, "one", first.TextContent())
+	assert.Equal(t, "two", iterator.NextNode().TextContent())
+	assert.True(t, iterator.PreviousNode().IsSameNode(iterator.ReferenceNode()))
+}
+
+func TestDocument_CreateRange(t *testing.T) {
+	document := browser.OpenDocument()
+
+	root := document.CreateElement("div")
+	root.SetInnerHTML(`<p>one</p><p>two</p>`)
+	document.Body().Append(root)
+	defer root.Remove()
+
+	r := document.CreateRange()
+	r.SelectNodeContents(root)
+	assert.Equal(t, "onetwo", r.String())
+	assert.Equal(t, 2, r.EndOffset())
+
+	r.SetStart(root.FirstElementChild().FirstChild(), 1)
+	assert.Equal(t, "netwo", r.String())
+	contents := r.CloneContents()
+	assert.Equal(t, 2, contents.ChildElementCount())
+	assert.Equal(t, "ne", contents.FirstElementChild().TextContent())
+
+	r.DeleteContents()
+	assert.True(t, r.Collapsed())
+	assert.Equal(t, "<p>o</p>", root.InnerHTML())
+
+	static := browser.NewStaticRange(root, 0, root, 1)
+	assert.False(t, static.Collapsed())
+}
+
+func TestMutationObserver(t *testing.T) {
+	document := browser.OpenDocument()
+
+	root := document.CreateElement("div")
+	root.SetInnerHTML(`<p>one</p>`)
+	observer := browser.NewMutationObserver(nil)
+	observer.Observe(root, spec.MutationObserverInit{ChildList: true, AttributeOldValue: true})
+	defer observer.Disconnect()
+
+	root.SetAttribute("class", "a")
+	root.SetAttribute("class", "b")
+	root.Append(document.CreateElement("hr"))
+
+	records := observer.TakeRecords()
+	require.Len(t, records, 3)
+	assert.Equal(t, spec.MutationAttributes, records[0].Type())
+	assert.Equal(t, "class", records[0].AttributeName())
+	assert.Equal(t, "a", records[1].OldValue())
+	assert.Equal(t, spec.MutationChildList, records[2].Type())
+	assert.Equal(t, 1, records[2].AddedNodes().Length())
+	assert.Equal(t, spec.NodeTypeElement, records[2].PreviousSibling().NodeType())
+}
+
+func TestEventTarget(t *testing.T) {
+	document := browser.OpenDocument()
+
+	root := document.CreateElement("div")
+	root.SetInnerHTML(`<p></p>`)
+	p := root.FirstElementChild()
+
+	var log []string
+	capture := spec.EventListenerFunc(func(event spec.Event) {
+		log = append(log, "capture")
+		assert.Equal(t, spec.EventPhaseCapturing, event.EventPhase())
+	})
+	bubble := spec.EventListenerFunc(func(event spec.Event) {
+		log = append(log, "bubble")
+		assert.Equal(t, spec.EventPhaseBubbling, event.EventPhase())
+		assert.True(t, event.Target().(spec.Node).IsSameNode(p))
+		assert.True(t, event.CurrentTarget().(spec.Node).IsSameNode(root))
+		event.PreventDefault()
+	})
+	root.AddEventListener("x", &capture, spec.AddEventListenerOptions{Capture: true})
+	root.AddEventListener("x", &bubble, spec.AddEventListenerOptions{})
+
+	event := browser.NewEvent("x", spec.EventInit{Bubbles: true, Cancelable: true})
+	assert.False(t, p.DispatchEvent(event))
+	assert.True(t, event.DefaultPrevented())
+	assert.Equal(t, []string{"capture", "bubble"}, log)
+
+	root.RemoveEventListener("x", &capture, spec.EventListenerOptions{Capture: true})
+	root.RemoveEventListener("x", &bubble, spec.EventListenerOptions{})
+	assert.True(t, p.DispatchEvent(browser.NewEvent("x", spec.EventInit{Bubbles: true})))
+	assert.Len(t, log, 2)
+}
+
+func TestElement_Content(t *testing.T) {
+	document := browser.OpenDocument()
+
+	root := document.CreateElement("div")
+	root.SetInnerHTML(`<template><p class="in-template">hello</p></template>`)
+	template, ok := root.FirstElementChild().(spec.HTMLTemplateElement)
+	require.True(t, ok)
+
+	content := template.Content()
+	require.NotNil(t, content)
+	assert.Equal(t, 1, content.ChildElementCount())
+	assert.Nil(t, root.QuerySelector(".in-template"))
+	assert.Nil(t, root.(spec.HTMLTemplateElement).Content())
+}
+
+func TestElement_InnerText(t *testing.T) {
+	document := browser.OpenDocument()
+
+	root := document.CreateElement("div")
+	root.(spec.InnerTextSetter).SetInnerText("one\ntwo")
+	assert.Equal(t, `one<br>two`, root.InnerHTML())
+}
+
+func TestElement_attributeNames(t *testing.T) {
+	document := browser.OpenDocument()
+
+	element := document.CreateElement("input")
+	assert.False(t, element.HasAttributes())
+	assert.Empty(t, element.GetAttributeNames())
+
+	assert.True(t, element.ToggleAttributeForce("disabled", true))
+	assert.True(t, element.ToggleAttributeForce("disabled", true))
+	element.SetAttribute("id", "a")
+	assert.True(t, element.HasAttributes())
+	assert.Equal(t, []string{"disabled", "id"}, element.GetAttributeNames())
+	assert.False(t, element.ToggleAttributeForce("disabled", false))
+	assert.Equal(t, []string{"id"}, element.GetAttributeNames())
+}
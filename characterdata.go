@@ -0,0 +1,78 @@
+package dom
+
+import (
+	"strconv"
+	"unicode/utf16"
+
+	"golang.org/x/net/html"
+)
+
+// utf16Length returns the length of s in UTF-16 code units.
+// See https://dom.spec.whatwg.org/#concept-node-length
+func utf16Length(s string) int {
+	n := 0
+	for _, r := range s {
+		n += utf16.RuneLen(r)
+	}
+	return n
+}
+
+// replaceData is based on https://dom.spec.whatwg.org/#concept-cd-replace
+// The offset and count are in UTF-16 code units. Go strings can not hold lone surrogates,
+// so splitting a surrogate pair replaces each half with U+FFFD.
+func replaceData(node *html.Node, offset, count int, data string) {
+	units := utf16.Encode([]rune(node.Data))
+	offset, count = characterDataRange(units, offset, count)
+	result := make([]uint16, 0, len(units)-count+len(data))
+	result = append(result, units[:offset]...)
+	for _, r := range data {
+		result = utf16.AppendRune(result, r)
+	}
+	result = append(result, units[offset+count:]...)
+	queueCharacterDataRecord(node)
+	rangesReplacedData(node, offset, count, utf16Length(data))
+	node.Data = string(utf16.Decode(result))
+}
+
+// setData is based on https://dom.spec.whatwg.org/#dom-characterdata-data
+func setData(node *html.Node, data string) {
+	queueCharacterDataRecord(node)
+	rangesReplacedData(node, 0, utf16Length(node.Data), utf16Length(data))
+	node.Data = data
+}
+
+// appendData is based on https://dom.spec.whatwg.org/#dom-characterdata-appenddata
+func appendData(node *html.Node, data string) {
+	queueCharacterDataRecord(node)
+	node.Data += data
+}
+
+// splitText is based on https://dom.spec.whatwg.org/#concept-text-split
+// The new node is inserted after node when node has a parent.
+func splitText(node *html.Node, offset int) *html.Node {
+	newNode := &html.Node{Type: html.TextNode, Data: substringData(node, offset, -1)}
+	if node.Parent != nil {
+		insertHTMLNode(node.Parent, newNode, node.NextSibling)
+		rangesSplitText(node, newNode, offset)
+	}
+	replaceData(node, offset, -1, "")
+	return newNode
+}
+
+// substringData is based on https://dom.spec.whatwg.org/#concept-cd-substring
+func substringData(node *html.Node, offset, count int) string {
+	units := utf16.Encode([]rune(node.Data))
+	offset, count = characterDataRange(units, offset, count)
+	return string(utf16.Decode(units[offset : offset+count]))
+}
+
+// characterDataRange panics with an index size error when offset is out of range and clamps count to the length.
+func characterDataRange(units []uint16, offset, count int) (int, int) {
+	if offset < 0 || offset > len(units) {
+		panic("dom: index size error: offset " + strconv.Itoa(offset) + " is out of range")
+	}
+	if count < 0 || offset+count > len(units) {
+		count = len(units) - offset
+	}
+	return offset, count
+}
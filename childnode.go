@@ -0,0 +1,101 @@
+package dom
+
+import (
+	"golang.org/x/net/html"
+
+	"github.com/typelate/dom/spec"
+)
+
+// before is based on https://dom.spec.whatwg.org/#dom-childnode-before
+func before(node *html.Node, nodes []spec.Node) {
+	parent := node.Parent
+	if parent == nil {
+		return
+	}
+	viablePreviousSibling := node.PrevSibling
+	for viablePreviousSibling != nil && containsHTMLNode(nodes, viablePreviousSibling) {
+		viablePreviousSibling = viablePreviousSibling.PrevSibling
+	}
+	inserted := convertNodes(parent, nodes)
+	reference := parent.FirstChild
+	if viablePreviousSibling != nil {
+		reference = viablePreviousSibling.NextSibling
+	}
+	insertHTMLNodes(parent, inserted, reference)
+}
+
+// after is based on https://dom.spec.whatwg.org/#dom-childnode-after
+func after(node *html.Node, nodes []spec.Node) {
+	parent := node.Parent
+	if parent == nil {
+		return
+	}
+	viableNextSibling := node.NextSibling
+	for viableNextSibling != nil && containsHTMLNode(nodes, viableNextSibling) {
+		viableNextSibling = viableNextSibling.NextSibling
+	}
+	inserted := convertNodes(parent, nodes)
+	insertHTMLNodes(parent, inserted, viableNextSibling)
+}
+
+// replaceWith is based on https://dom.spec.whatwg.org/#dom-childnode-replacewith
+func replaceWith(node *html.Node, nodes []spec.Node) {
+	parent := node.Parent
+	if parent == nil {
+		return
+	}
+	viableNextSibling := node.NextSibling
+	for viableNextSibling != nil && containsHTMLNode(nodes, viableNextSibling) {
+		viableNextSibling = viableNextSibling.NextSibling
+	}
+	inserted := convertNodes(parent, nodes)
+	if node.Parent == parent {
+		replaceHTMLNode(node, inserted)
+		return
+	}
+	insertHTMLNodes(parent, inserted, viableNextSibling)
+}
+
+func remove(node *html.Node) { removeHTMLNode(node) }
+
+// convertNodes is based on https://dom.spec.whatwg.org/#converting-nodes-into-a-node
+// The children of fragments are moved out of the fragment and every node is removed from its parent.
+// It panics if a node is an inclusive ancestor of parent.
+func convertNodes(parent *html.Node, nodes []spec.Node) []*html.Node {
+	var result []*html.Node
+	for _, node := range nodes {
+		if fragment, ok := node.(*DocumentFragment); ok {
+			result = append(result, fragment.children()...)
+			continue
+		}
+		result = append(result, domNodeToHTMLNode(node))
+	}
+	for _, n := range result {
+		for p := parent; p != nil; p = p.Parent {
+			if p == n {
+				panic("dom: hierarchy request error: a node can not be inserted into itself or its descendants")
+			}
+		}
+	}
+	for _, node := range nodes {
+		if fragment, ok := node.(*DocumentFragment); ok {
+			fragment.nodes = nil
+		}
+	}
+	for _, n := range result {
+		removeHTMLNode(n)
+	}
+	return result
+}
+
+func containsHTMLNode(nodes []spec.Node, n *html.Node) bool {
+	for _, node := range nodes {
+		if _, ok := node.(*DocumentFragment); ok {
+			continue
+		}
+		if domNodeToHTMLNode(node) == n {
+			return true
+		}
+	}
+	return false
+}
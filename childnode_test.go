@@ -0,0 +1,149 @@
+package dom_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/net/html"
+
+	"github.com/typelate/dom"
+	"github.com/typelate/dom/spec"
+)
+
+func parseChildNodeDocument(t *testing.T) spec.Document {
+	t.Helper()
+	// language=html
+	node, err := html.Parse(strings.NewReader(`<!DOCTYPE html><html><head></head><body><ul><li id="a">a</li><li id="b">b</li><li id="c">c</li></ul></body></html>`))
+	require.NoError(t, err)
+	return dom.NewNode(node).(spec.Document)
+}
+
+func TestChildNode_Before(t *testing.T) {
+	t.Run("elements", func(t *testing.T) {
+		document := parseChildNodeDocument(t)
+		b := document.QuerySelector("#b")
+		x := document.CreateElement("li")
+		x.SetAttribute("id", "x")
+
+		b.Before(x, document.CreateTextNode("y"))
+
+		assert.Equal(t, `<li id="a">a</li><li id="x"></li>y<li id="b">b</li><li id="c">c</li>`, document.QuerySelector("ul").InnerHTML())
+	})
+	t.Run("viable previous sibling", func(t *testing.T) {
+		document := parseChildNodeDocument(t)
+		a := document.QuerySelector("#a")
+		b := document.QuerySelector("#b")
+		c := document.QuerySelector("#c")
+
+		b.Before(c, a)
+
+		assert.Equal(t, `<li id="c">c</li><li id="a">a</li><li id="b">b</li>`, document.QuerySelector("ul").InnerHTML())
+	})
+	t.Run("fragment", func(t *testing.T) {
+		document := parseChildNodeDocument(t)
+		fragment := parseDocumentFragment(t, `<li>1</li><li>2</li>`)
+
+		document.QuerySelector("#a").Before(fragment)
+
+		assert.Equal(t, `<li>1</li><li>2</li><li id="a">a</li><li id="b">b</li><li id="c">c</li>`, document.QuerySelector("ul").InnerHTML())
+		assert.Equal(t, 0, fragment.ChildElementCount())
+	})
+	t.Run("no parent", func(t *testing.T) {
+		document := parseChildNodeDocument(t)
+		el := document.CreateElement("div")
+		assert.NotPanics(t, func() {
+			el.Before(document.CreateTextNode("x"))
+		})
+	})
+	t.Run("hierarchy", func(t *testing.T) {
+		document := parseChildNodeDocument(t)
+		ul := document.QuerySelector("ul")
+		assert.Panics(t, func() {
+			document.QuerySelector("#a").Before(ul)
+		})
+	})
+}
+
+func TestChildNode_After(t *testing.T) {
+	t.Run("text", func(t *testing.T) {
+		document := parseChildNodeDocument(t)
+		text := document.QuerySelector("#a").FirstChild()
+
+		text.After(document.CreateElement("br"), document.CreateTextNode("!"))
+
+		assert.Equal(t, `<li id="a">a<br/>!</li>`, document.QuerySelector("#a").OuterHTML())
+	})
+	t.Run("viable next sibling", func(t *testing.T) {
+		document := parseChildNodeDocument(t)
+		a := document.QuerySelector("#a")
+		b := document.QuerySelector("#b")
+		c := document.QuerySelector("#c")
+
+		a.After(c, b)
+
+		assert.Equal(t, `<li id="a">a</li><li id="c">c</li><li id="b">b</li>`, document.QuerySelector("ul").InnerHTML())
+	})
+	t.Run("last child", func(t *testing.T) {
+		document := parseChildNodeDocument(t)
+		comment := document.CreateComment("end")
+
+		document.QuerySelector("#c").After(comment)
+
+		assert.True(t, document.QuerySelector("ul").LastChild().IsSameNode(comment))
+	})
+}
+
+func TestChildNode_ReplaceWith(t *testing.T) {
+	t.Run("elements", func(t *testing.T) {
+		document := parseChildNodeDocument(t)
+		b := document.QuerySelector("#b")
+		x := document.CreateElement("li")
+
+		b.ReplaceWith(x, document.CreateComment("y"))
+
+		assert.Equal(t, `<li id="a">a</li><li></li><!--y--><li id="c">c</li>`, document.QuerySelector("ul").InnerHTML())
+		assert.Nil(t, b.ParentNode())
+	})
+	t.Run("with itself", func(t *testing.T) {
+		document := parseChildNodeDocument(t)
+		b := document.QuerySelector("#b")
+		c := document.QuerySelector("#c")
+
+		b.ReplaceWith(c, b)
+
+		assert.Equal(t, `<li id="a">a</li><li id="c">c</li><li id="b">b</li>`, document.QuerySelector("ul").InnerHTML())
+	})
+	t.Run("with nothing", func(t *testing.T) {
+		document := parseChildNodeDocument(t)
+
+		document.QuerySelector("#b").ReplaceWith()
+
+		assert.Equal(t, `<li id="a">a</li><li id="c">c</li>`, document.QuerySelector("ul").InnerHTML())
+	})
+	t.Run("fragment", func(t *testing.T) {
+		document := parseChildNodeDocument(t)
+		fragment := parseDocumentFragment(t, `<li>1</li><li>2</li>`)
+
+		document.QuerySelector("#c").ReplaceWith(fragment)
+
+		assert.Equal(t, `<li id="a">a</li><li id="b">b</li><li>1</li><li>2</li>`, document.QuerySelector("ul").InnerHTML())
+	})
+}
+
+func TestChildNode_Remove(t *testing.T) {
+	document := parseChildNodeDocument(t)
+	b := document.QuerySelector("#b")
+
+	b.Remove()
+
+	assert.Equal(t, `<li id="a">a</li><li id="c">c</li>`, document.QuerySelector("ul").InnerHTML())
+	assert.Nil(t, b.ParentNode())
+	assert.False(t, b.IsConnected())
+	assert.NotPanics(t, func() { b.Remove() })
+
+	doctype := document.Doctype()
+	doctype.Remove()
+	assert.Nil(t, document.Doctype())
+}
@@ -0,0 +1,67 @@
+package dom
+
+import (
+	"slices"
+	"weak"
+
+	"golang.org/x/net/html"
+
+	"github.com/typelate/dom/spec"
+)
+
+// liveElements is a live collection of the descendant elements of root that match.
+// It is based on https://dom.spec.whatwg.org/#interface-htmlcollection
+// The matching elements are cached with the generation of the tree that contains root
+// and collected again when that tree changes. Mutations made directly to html.Node
+// values are not tracked, so the collection does not reflect them.
+type liveElements struct {
+	root  *html.Node
+	match func(*html.Node) bool
+
+	built      bool
+	tree       weak.Pointer[html.Node]
+	generation uint64
+	nodes      []*html.Node
+}
+
+var _ spec.ElementCollection = (*liveElements)(nil)
+
+func newLiveElements(root *html.Node, match func(*html.Node) bool) *liveElements {
+	return &liveElements{root: root, match: match}
+}
+
+func (list *liveElements) elements() []*html.Node {
+	tree, generation := weak.Make(treeRoot(list.root)), treeGeneration(list.root)
+	if list.built && list.tree == tree && list.generation == generation {
+		return list.nodes
+	}
+	list.built, list.tree, list.generation = true, tree, generation
+	list.nodes = list.nodes[:0]
+	for c := list.root.FirstChild; c != nil; c = c.NextSibling {
+		walkNodes(c, func(n *html.Node) bool {
+			if n.Type == html.ElementNode && list.match(n) {
+				list.nodes = append(list.nodes, n)
+			}
+			return false
+		})
+	}
+	return list.nodes
+}
+
+func (list *liveElements) Length() int { return len(list.elements()) }
+
+func (list *liveElements) Item(index int) spec.Element {
+	return elementList(list.elements()).Item(index)
+}
+
+func (list *liveElements) NamedItem(name string) spec.Element {
+	return elementList(list.elements()).NamedItem(name)
+}
+
+// isHTMLElement reports whether node is an element in the HTML namespace with one of the local names.
+func isHTMLElement(node *html.Node, localNames ...string) bool {
+	if node == nil || node.Type != html.ElementNode || node.Namespace != "" {
+		return false
+	}
+	return slices.Contains(localNames, node.Data)
+}
@@ -0,0 +1,57 @@
+package dom
+
+import (
+	"golang.org/x/net/html"
+
+	"github.com/typelate/dom/spec"
+)
+
+type Comment struct {
+	node *html.Node
+}
+
+func (c *Comment) Data() string     { return c.node.Data }
+func (c *Comment) SetData(d string) { setData(c.node, d) }
+
+func (c *Comment) NodeType() spec.NodeType         { return nodeType(c.node.Type) }
+func (c *Comment) IsConnected() bool               { return isConnected(c.node) }
+func (c *Comment) OwnerDocument() spec.Document    { return ownerDocument(c.node) }
+func (c *Comment) Length() int                     { return utf16Length(c.node.Data) }
+func (c *Comment) ParentNode() spec.Node           { return parentNode(c.node) }
+func (c *Comment) ParentElement() spec.Element     { return parentElement(c.node) }
+func (c *Comment) PreviousSibling() spec.ChildNode { return previousSibling(c.node) }
+func (c *Comment) NextSibling() spec.ChildNode     { return nextSibling(c.node) }
+func (c *Comment) Before(nodes ...spec.Node)       { before(c.node, nodes) }
+func (c *Comment) After(nodes ...spec.Node)        { after(c.node, nodes) }
+func (c *Comment) ReplaceWith(nodes ...spec.Node)  { replaceWith(c.node, nodes) }
+func (c *Comment) Remove()                         { remove(c.node) }
+func (c *Comment) TextContent() string             { return c.node.Data }
+func (c *Comment) CloneNode(_ bool) spec.Node {
+	return &Comment{
+		node: &html.Node{
+			Type: html.CommentNode,
+			Data: c.node.Data,
+		},
+	}
+}
+
+func (c *Comment) LookupPrefix(namespace string) string {
+	return lookupPrefix(c.node, namespace)
+}
+
+func (c *Comment) LookupNamespaceURI(prefix string) string {
+	return locateNamespace(c.node, prefix)
+}
+
+func (c *Comment) IsDefaultNamespace(namespace string) bool {
+	return isDefaultNamespace(c.node, namespace)
+}
+
+func (c *Comment) CompareDocumentPosition(other spec.Node) spec.DocumentPosition {
+	return compareDocumentPosition(c.node, other)
+}
+
+func (c *Comment) IsSameNode(other spec.Node) bool  { return isSameNode(c.node, other) }
+func (c *Comment) IsEqualNode(other spec.Node) bool { return isEqualNode(c, other) }
+
+func (c *Comment) String() string { return outerHTML(c.node) }
@@ -0,0 +1,103 @@
+package dom
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/net/html"
+
+	"github.com/typelate/dom/spec"
+)
+
+var _ spec.Comment = (*Comment)(nil)
+
+func TestComment_Data(t *testing.T) {
+	// language=html
+	textHTML := `<!DOCTYPE html>
+<html lang="us-en">
+<head><title></title></head>
+<body><!-- marker --></body></html>`
+	_, body := parseDocument(t, textHTML, "body")
+	comment, ok := body.FirstChild().(*Comment)
+	require.True(t, ok)
+
+	require.Equal(t, " marker ", comment.Data())
+	comment.SetData("replaced")
+	require.Equal(t, "replaced", comment.Data())
+	require.Equal(t, "<!--replaced-->", body.InnerHTML())
+}
+
+func TestComment_NodeType(t *testing.T) {
+	comment := &Comment{
+		node: &html.Node{Type: html.CommentNode},
+	}
+	assert.Equal(t, spec.NodeTypeComment, comment.NodeType())
+}
+
+func TestComment_traversal(t *testing.T) {
+	// language=html
+	textHTML := `<!DOCTYPE html>
+<html lang="us-en">
+<head><title></title></head>
+<body><!-- start --><span id="middle"></span><!-- end --></body></html>`
+	document, body := parseDocument(t, textHTML, "body")
+
+	nodes := body.ChildNodes()
+	require.Equal(t, 3, nodes.Length())
+	assert.Equal(t, spec.NodeTypeComment, nodes.Item(0).NodeType())
+	assert.Equal(t, spec.NodeTypeElement, nodes.Item(1).NodeType())
+	assert.Equal(t, spec.NodeTypeComment, nodes.Item(2).NodeType())
+
+	start, ok := body.FirstChild().(spec.Comment)
+	require.True(t, ok)
+	assert.Equal(t, " start ", start.Data())
+	assert.True(t, start.IsConnected())
+	assert.True(t, start.OwnerDocument().IsSameNode(document))
+	assert.True(t, start.ParentElement().IsSameNode(body))
+	assert.Nil(t, start.PreviousSibling())
+
+	middle := start.NextSibling()
+	require.NotNil(t, middle)
+	end, ok := middle.NextSibling().(spec.Comment)
+	require.True(t, ok)
+	assert.Equal(t, " end ", end.Data())
+	assert.True(t, end.PreviousSibling().IsSameNode(middle))
+	assert.True(t, body.LastChild().IsSameNode(end))
+
+	assert.Zero(t, body.TextContent())
+	assert.Equal(t, " end ", end.TextContent())
+}
+
+func TestComment_CloneNode(t *testing.T) {
+	// language=html
+	textHTML := `<!DOCTYPE html>
+<html lang="us-en">
+<head><title></title></head>
+<body><!--peach--></body>
+</html>`
+	_, body := parseDocument(t, textHTML, "body")
+	comment := &Comment{
+		node: body.node.FirstChild,
+	}
+
+	cloned := comment.CloneNode(true)
+
+	require.Equal(t, "peach", cloned.(*Comment).node.Data)
+	require.Nil(t, cloned.(*Comment).node.Parent)
+	require.False(t, comment.IsSameNode(cloned))
+}
+
+func TestComment_appendChild(t *testing.T) {
+	var document *Document
+	div := document.CreateElement("div")
+	div.AppendChild(document.CreateTextNode("a"))
+	div.AppendChild(document.CreateComment("b"))
+	assert.Equal(t, "a<!--b-->", div.InnerHTML())
+
+	comment, ok := div.LastChild().(*Comment)
+	require.True(t, ok)
+	assert.Equal(t, 1, comment.Length())
+	div.RemoveChild(comment)
+	assert.Equal(t, "a", div.InnerHTML())
+}
@@ -0,0 +1,104 @@
+package dom
+
+import (
+	"iter"
+	"strings"
+
+	"golang.org/x/net/html"
+
+	"github.com/typelate/dom/spec"
+)
+
+// dataset is a live view of the data-* attributes on an element.
+// It is based on https://html.spec.whatwg.org/multipage/dom.html#dom-dataset
+type dataset struct {
+	node *html.Node
+}
+
+var _ spec.DOMStringMap = dataset{}
+
+func (d dataset) Get(name string) (string, bool) {
+	for n, value := range d.All() {
+		if n == name {
+			return value, true
+		}
+	}
+	return "", false
+}
+
+func (d dataset) Set(name, value string) { setAttribute(d.node, datasetAttributeName(name), value) }
+
+// Delete is based on https://html.spec.whatwg.org/multipage/dom.html#dom-domstringmap-removeitem
+// It does nothing when no data-* attribute has the name.
+func (d dataset) Delete(name string) {
+	if attributeName, ok := convertDatasetName(name); ok {
+		removeAttribute(d.node, attributeName)
+	}
+}
+
+func (d dataset) All() iter.Seq2[string, string] {
+	return func(yield func(string, string) bool) {
+		for _, att := range d.node.Attr {
+			if att.Namespace != "" {
+				continue
+			}
+			name, ok := strings.CutPrefix(att.Key, "data-")
+			if !ok {
+				continue
+			}
+			if !yield(datasetPropertyName(name), att.Val) {
+				return
+			}
+		}
+	}
+}
+
+// datasetPropertyName converts the part of an attribute name after "data-" to a property name.
+// It follows the steps in https://html.spec.whatwg.org/multipage/dom.html#concept-domstringmap-pairs
+func datasetPropertyName(name string) string {
+	var sb strings.Builder
+	for i := 0; i < len(name); i++ {
+		c := name[i]
+		if c == '-' && i+1 < len(name) && isASCIILower(name[i+1]) {
+			sb.WriteByte(name[i+1] - 'a' + 'A')
+			i++
+			continue
+		}
+		sb.WriteByte(c)
+	}
+	return sb.String()
+}
+
+// datasetAttributeName converts a property name to a data-* attribute name.
+// It follows the steps in https://html.spec.whatwg.org/multipage/dom.html#dom-domstringmap-setitem
+// Instead of throwing a SyntaxError it panics.
+func datasetAttributeName(name string) string {
+	attributeName, ok := convertDatasetName(name)
+	if !ok {
+		panic("dom: dataset name " + name + " must not contain a hyphen followed by a lowercase letter")
+	}
+	return attributeName
+}
+
+// convertDatasetName converts a property name to a data-* attribute name.
+// It reports false when the name contains a hyphen followed by a lowercase letter
+// because no attribute name converts to such a property name.
+func convertDatasetName(name string) (string, bool) {
+	var sb strings.Builder
+	sb.WriteString("data-")
+	for i := 0; i < len(name); i++ {
+		c := name[i]
+		if c == '-' && i+1 < len(name) && isASCIILower(name[i+1]) {
+			return "", false
+		}
+		if 'A' <= c && c <= 'Z' {
+			sb.WriteByte('-')
+			sb.WriteByte(c - 'A' + 'a')
+			continue
+		}
+		sb.WriteByte(c)
+	}
+	return sb.String(), true
+}
+
+func isASCIILower(c byte) bool { return 'a' <= c && c <= 'z' }
@@ -0,0 +1,88 @@
+package dom
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestElement_Dataset(t *testing.T) {
+	t.Run("Get", func(t *testing.T) {
+		// language=html
+		_, el := parseDocument(t, `<!DOCTYPE html><html><head></head><body><p id="a" data-type="*errors.errorString" data-foo-bar="1" data-x-1="2" data-="3"></p></body></html>`, "#a")
+
+		dataset := el.Dataset()
+
+		value, ok := dataset.Get("type")
+		assert.True(t, ok)
+		assert.Equal(t, "*errors.errorString", value)
+
+		value, ok = dataset.Get("fooBar")
+		assert.True(t, ok)
+		assert.Equal(t, "1", value)
+
+		value, ok = dataset.Get("x-1")
+		assert.True(t, ok)
+		assert.Equal(t, "2", value)
+
+		value, ok = dataset.Get("")
+		assert.True(t, ok)
+		assert.Equal(t, "3", value)
+
+		_, ok = dataset.Get("foo-bar")
+		assert.False(t, ok)
+		_, ok = dataset.Get("id")
+		assert.False(t, ok)
+	})
+	t.Run("All", func(t *testing.T) {
+		// language=html
+		_, el := parseDocument(t, `<!DOCTYPE html><html><head></head><body><p id="a" data-one="1" class="x" data-two-words="2"></p></body></html>`, "#a")
+
+		var names, values []string
+		for name, value := range el.Dataset().All() {
+			names = append(names, name)
+			values = append(values, value)
+		}
+		assert.Equal(t, []string{"one", "twoWords"}, names)
+		assert.Equal(t, []string{"1", "2"}, values)
+
+		for range el.Dataset().All() {
+			break
+		}
+	})
+	t.Run("Set", func(t *testing.T) {
+		// language=html
+		_, el := parseDocument(t, `<!DOCTYPE html><html><head></head><body><p id="a" data-foo-bar="1"></p></body></html>`, "#a")
+
+		dataset := el.Dataset()
+		dataset.Set("fooBar", "2")
+		dataset.Set("htmxTarget", "#main")
+
+		assert.Equal(t, `<p id="a" data-foo-bar="2" data-htmx-target="#main"></p>`, el.OuterHTML())
+		value, ok := dataset.Get("htmxTarget")
+		require.True(t, ok)
+		assert.Equal(t, "#main", value)
+
+		assert.Panics(t, func() { dataset.Set("foo-bar", "") })
+	})
+	t.Run("Delete", func(t *testing.T) {
+		// language=html
+		_, el := parseDocument(t, `<!DOCTYPE html><html><head></head><body><p id="a" data-foo-bar="1" data-baz="2"></p></body></html>`, "#a")
+
+		el.Dataset().Delete("fooBar")
+		el.Dataset().Delete("missing")
+
+		assert.Equal(t, `<p id="a" data-baz="2"></p>`, el.OuterHTML())
+	})
+	t.Run("Delete a name that is not a property name", func(t *testing.T) {
+		// language=html
+		_, el := parseDocument(t, `<!DOCTYPE html><html><head></head><body><p id="a" data--a="1" data-foo-bar="2"></p></body></html>`, "#a")
+
+		assert.NotPanics(t, func() {
+			el.Dataset().Delete("-a")
+			el.Dataset().Delete("foo-bar")
+		})
+		assert.Equal(t, `<p id="a" data--a="1" data-foo-bar="2"></p>`, el.OuterHTML())
+	})
+}
@@ -0,0 +1,56 @@
+package dom
+
+import (
+	"golang.org/x/net/html"
+
+	"github.com/typelate/dom/spec"
+)
+
+type DocumentType struct {
+	node *html.Node
+}
+
+func (d *DocumentType) Name() string     { return d.node.Data }
+func (d *DocumentType) PublicID() string { return getAttribute(d.node, "public") }
+func (d *DocumentType) SystemID() string { return getAttribute(d.node, "system") }
+
+func (d *DocumentType) NodeType() spec.NodeType          { return nodeType(d.node.Type) }
+func (d *DocumentType) IsConnected() bool                { return isConnected(d.node) }
+func (d *DocumentType) OwnerDocument() spec.Document     { return ownerDocument(d.node) }
+func (d *DocumentType) ParentNode() spec.Node            { return parentNode(d.node) }
+func (d *DocumentType) ParentElement() spec.Element      { return parentElement(d.node) }
+func (d *DocumentType) PreviousSibling() spec.ChildNode  { return previousSibling(d.node) }
+func (d *DocumentType) NextSibling() spec.ChildNode      { return nextSibling(d.node) }
+func (d *DocumentType) Before(nodes ...spec.Node)        { before(d.node, nodes) }
+func (d *DocumentType) After(nodes ...spec.Node)         { after(d.node, nodes) }
+func (d *DocumentType) ReplaceWith(nodes ...spec.Node)   { replaceWith(d.node, nodes) }
+func (d *DocumentType) Remove()                          { remove(d.node) }
+func (d *DocumentType) CloneNode(deep bool) spec.Node    { return NewNode(cloneNode(d.node, deep)) }
+func (d *DocumentType) IsSameNode(other spec.Node) bool  { return isSameNode(d.node, other) }
+func (d *DocumentType) IsEqualNode(other spec.Node) bool { return isEqualNode(d, other) }
+
+// Length returns zero. See https://dom.spec.whatwg.org/#concept-node-length
+func (d *DocumentType) Length() int { return 0 }
+
+// TextContent returns an empty string.
+// The spec says it should return null
+// https://developer.mozilla.org/en-US/docs/Web/API/Node/textContent
+func (d *DocumentType) TextContent() string { return "" }
+
+func (d *DocumentType) LookupPrefix(namespace string) string {
+	return lookupPrefix(d.node, namespace)
+}
+
+func (d *DocumentType) LookupNamespaceURI(prefix string) string {
+	return locateNamespace(d.node, prefix)
+}
+
+func (d *DocumentType) IsDefaultNamespace(namespace string) bool {
+	return isDefaultNamespace(d.node, namespace)
+}
+
+func (d *DocumentType) CompareDocumentPosition(other spec.Node) spec.DocumentPosition {
+	return compareDocumentPosition(d.node, other)
+}
+
+func (d *DocumentType) String() string { return outerHTML(d.node) }
@@ -0,0 +1,69 @@
+package dom
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/net/html"
+
+	"github.com/typelate/dom/spec"
+)
+
+var _ spec.DocumentType = (*DocumentType)(nil)
+
+func TestDocument_Doctype(t *testing.T) {
+	t.Run("html", func(t *testing.T) {
+		// language=html
+		document, _ := parseDocument(t, `<!DOCTYPE html><html lang="us-en"><head><title></title></head><body></body></html>`, "")
+
+		doctype := document.Doctype()
+		require.NotNil(t, doctype)
+		assert.Equal(t, spec.NodeTypeDocumentType, doctype.NodeType())
+		assert.Equal(t, "html", doctype.Name())
+		assert.Zero(t, doctype.PublicID())
+		assert.Zero(t, doctype.SystemID())
+		assert.Zero(t, doctype.Length())
+		assert.Zero(t, doctype.TextContent())
+		assert.True(t, doctype.IsConnected())
+		assert.True(t, doctype.OwnerDocument().IsSameNode(document))
+		assert.True(t, doctype.ParentNode().IsSameNode(document))
+		assert.Nil(t, doctype.ParentElement())
+		assert.Nil(t, doctype.PreviousSibling())
+
+		next, ok := doctype.NextSibling().(spec.Element)
+		require.True(t, ok)
+		assert.Equal(t, "HTML", next.TagName())
+		assert.True(t, next.PreviousSibling().IsSameNode(doctype))
+	})
+	t.Run("legacy identifiers", func(t *testing.T) {
+		// language=html
+		document, _ := parseDocument(t, `<!DOCTYPE html PUBLIC "-//W3C//DTD HTML 4.01//EN" "http://www.w3.org/TR/html4/strict.dtd"><html><head></head><body></body></html>`, "")
+
+		doctype := document.Doctype()
+		require.NotNil(t, doctype)
+		assert.Equal(t, "html", doctype.Name())
+		assert.Equal(t, "-//W3C//DTD HTML 4.01//EN", doctype.PublicID())
+		assert.Equal(t, "http://www.w3.org/TR/html4/strict.dtd", doctype.SystemID())
+	})
+	t.Run("missing", func(t *testing.T) {
+		// language=html
+		document, _ := parseDocument(t, `<html><head></head><body></body></html>`, "")
+		assert.Nil(t, document.Doctype())
+	})
+}
+
+func TestDocumentType_CloneNode(t *testing.T) {
+	parsedDocument, err := html.Parse(strings.NewReader(`<!DOCTYPE html PUBLIC "-//W3C//DTD HTML 4.01//EN"><html></html>`))
+	require.NoError(t, err)
+	doctype := &DocumentType{node: parsedDocument.FirstChild}
+
+	cloned, ok := doctype.CloneNode(false).(*DocumentType)
+	require.True(t, ok)
+	assert.False(t, cloned.IsSameNode(doctype))
+	assert.Nil(t, cloned.node.Parent)
+	assert.Equal(t, "html", cloned.Name())
+	assert.Equal(t, "-//W3C//DTD HTML 4.01//EN", cloned.PublicID())
+	assert.Equal(t, `<!DOCTYPE html PUBLIC "-//W3C//DTD HTML 4.01//EN">`, cloned.String())
+}
@@ -15,17 +15,165 @@ type Document struct {
 	node *html.Node
 }
 
-func (d *Document) Head() spec.Element { return d.QuerySelector("head") }
-func (d *Document) Body() spec.Element { return d.QuerySelector("body") }
+func (d *Document) Doctype() spec.DocumentType {
+	for c := d.node.FirstChild; c != nil; c = c.NextSibling {
+		if c.Type == html.DoctypeNode {
+			return &DocumentType{node: c}
+		}
+	}
+	return nil
+}
+
+// GetElementById uses an index that is rebuilt after the tree is mutated through this package.
+func (d *Document) GetElementById(id string) spec.Element {
+	return htmlNodeToDomElement(getElementByID(d.node, id))
+}
+
+// DocumentElement is based on https://dom.spec.whatwg.org/#dom-document-documentelement
+func (d *Document) DocumentElement() spec.Element {
+	return htmlNodeToDomElement(documentElement(d.node))
+}
+
+// Head is based on https://html.spec.whatwg.org/multipage/dom.html#dom-document-head
+func (d *Document) Head() spec.Element { return htmlNodeToDomElement(documentHead(d.node)) }
+
+// Body is based on https://html.spec.whatwg.org/multipage/dom.html#dom-document-body
+func (d *Document) Body() spec.Element {
+	return htmlNodeToDomElement(firstChildElement(documentRootHTMLElement(d.node), func(n *html.Node) bool {
+		return isHTMLElement(n, "body", "frameset")
+	}))
+}
+
+// Title is based on https://html.spec.whatwg.org/multipage/dom.html#document.title
+// Whitespace is stripped and collapsed.
+func (d *Document) Title() string {
+	title := documentTitle(d.node)
+	if title == nil {
+		return ""
+	}
+	var sb strings.Builder
+	for c := title.FirstChild; c != nil; c = c.NextSibling {
+		if c.Type == html.TextNode {
+			sb.WriteString(c.Data)
+		}
+	}
+	return strings.Join(strings.FieldsFunc(sb.String(), isASCIIWhitespace), " ")
+}
+
+// SetTitle is based on https://html.spec.whatwg.org/multipage/dom.html#document.title
+// When the document does not have a title element, one is added to the head.
+// Nothing happens if there is neither a title element nor a head.
+func (d *Document) SetTitle(title string) {
+	element := documentTitle(d.node)
+	if element == nil {
+		root := documentElement(d.node)
+		switch {
+		case isSVGRootElement(root):
+			element = &html.Node{Type: html.ElementNode, Namespace: "svg", Data: "title"}
+			insertHTMLNode(root, element, root.FirstChild)
+		case documentHead(d.node) != nil:
+			element = &html.Node{Type: html.ElementNode, Data: atom.Title.String(), DataAtom: atom.Title}
+			insertHTMLNode(documentHead(d.node), element, nil)
+		default:
+			return
+		}
+	}
+	var nodes []*html.Node
+	if title != "" {
+		nodes = append(nodes, &html.Node{Type: html.TextNode, Data: title})
+	}
+	replaceAllHTMLNodes(element, nodes)
+}
+
+// Forms is based on https://html.spec.whatwg.org/multipage/dom.html#dom-document-forms
+func (d *Document) Forms() spec.ElementCollection { return d.htmlElements("form") }
+
+// Images is based on https://html.spec.whatwg.org/multipage/dom.html#dom-document-images
+func (d *Document) Images() spec.ElementCollection { return d.htmlElements("img") }
+
+// Embeds is based on https://html.spec.whatwg.org/multipage/dom.html#dom-document-embeds
+func (d *Document) Embeds() spec.ElementCollection { return d.htmlElements("embed") }
+
+// Scripts is based on https://html.spec.whatwg.org/multipage/dom.html#dom-document-scripts
+func (d *Document) Scripts() spec.ElementCollection { return d.htmlElements("script") }
+
+// Links is based on https://html.spec.whatwg.org/multipage/dom.html#dom-document-links
+func (d *Document) Links() spec.ElementCollection {
+	return newLiveElements(d.node, func(n *html.Node) bool {
+		return isHTMLElement(n, "a", "area") && attributeIndex(n, "href") >= 0
+	})
+}
+
+func (d *Document) htmlElements(localName string) spec.ElementCollection {
+	return newLiveElements(d.node, func(n *html.Node) bool {
+		return isHTMLElement(n, localName)
+	})
+}
+
+func documentElement(document *html.Node) *html.Node {
+	return firstChildElement(document, func(*html.Node) bool { return true })
+}
+
+func documentRootHTMLElement(document *html.Node) *html.Node {
+	if root := documentElement(document); isHTMLElement(root, "html") {
+		return root
+	}
+	return nil
+}
+
+func documentHead(document *html.Node) *html.Node {
+	return firstChildElement(documentRootHTMLElement(document), func(n *html.Node) bool {
+		return isHTMLElement(n, "head")
+	})
+}
+
+func isSVGRootElement(node *html.Node) bool {
+	return node != nil && node.Type == html.ElementNode && node.Namespace == "svg" && node.Data == "svg"
+}
+
+// documentTitle is based on https://html.spec.whatwg.org/multipage/dom.html#the-title-element-2
+func documentTitle(document *html.Node) *html.Node {
+	if root := documentElement(document); isSVGRootElement(root) {
+		return firstChildElement(root, func(n *html.Node) bool {
+			return n.Namespace == "svg" && n.Data == "title"
+		})
+	}
+	var title *html.Node
+	walkNodes(document, func(n *html.Node) bool {
+		if isHTMLElement(n, "title") {
+			title = n
+			return true
+		}
+		return false
+	})
+	return title
+}
 
-func (d *Document) String() string                  { return outerHTML(d.node) }
-func (d *Document) NodeType() spec.NodeType         { return nodeType(d.node.Type) }
-func (d *Document) CloneNode(deep bool) spec.Node   { return NewNode(cloneNode(d.node, deep)) }
-func (d *Document) IsSameNode(other spec.Node) bool { return isSameNode(d.node, other) }
+func firstChildElement(parent *html.Node, match func(*html.Node) bool) *html.Node {
+	if parent == nil {
+		return nil
+	}
+	for c := parent.FirstChild; c != nil; c = c.NextSibling {
+		if c.Type == html.ElementNode && match(c) {
+			return c
+		}
+	}
+	return nil
+}
+
+func (d *Document) String() string                   { return outerHTML(d.node) }
+func (d *Document) NodeType() spec.NodeType          { return nodeType(d.node.Type) }
+func (d *Document) CloneNode(deep bool) spec.Node    { return NewNode(cloneNode(d.node, deep)) }
+func (d *Document) IsSameNode(other spec.Node) bool  { return isSameNode(d.node, other) }
+func (d *Document) IsEqualNode(other spec.Node) bool { return isEqualNode(d, other) }
 func (d *Document) GetElementsByTagName(name string) spec.ElementCollection {
 	return getElementsByTagName(d.node, name)
 }
 
+func (d *Document) GetElementsByTagNameNS(namespace, localName string) spec.ElementCollection {
+	return getElementsByTagNameNS(d.node, namespace, localName)
+}
+
 func (d *Document) GetElementsByClassName(name string) spec.ElementCollection {
 	return getElementsByClassName(d.node, name)
 }
@@ -46,6 +194,7 @@ func (d *Document) QuerySelectorSequence(query string) iter.Seq[spec.Element] {
 }
 
 func (d *Document) Contains(other spec.Node) bool { return contains(d.node, other) }
+func (d *Document) Normalize()                    { normalize(d.node) }
 
 func (d *Document) CompareDocumentPosition(other spec.Node) spec.DocumentPosition {
 	return compareDocumentPosition(d.node, other)
@@ -81,6 +230,12 @@ func (*Document) CreateElementIs(localName, is string) spec.Element {
 	}
 }
 
+// CreateElementNS panics when namespace is empty because x/net/html
+// can not represent elements in the null namespace.
+func (*Document) CreateElementNS(namespace, qualifiedName string) spec.Element {
+	return &Element{node: createElementNS(namespace, qualifiedName)}
+}
+
 func (*Document) CreateTextNode(text string) spec.Text {
 	return &Text{
 		node: &html.Node{
@@ -89,3 +244,73 @@ func (*Document) CreateTextNode(text string) spec.Text {
 		},
 	}
 }
+
+func (*Document) CreateComment(data string) spec.Comment {
+	return &Comment{
+		node: &html.Node{
+			Type: html.CommentNode,
+			Data: data,
+		},
+	}
+}
+
+func (*Document) CreateDocumentFragment() spec.DocumentFragment {
+	return &DocumentFragment{}
+}
+
+// CreateAttribute is based on https://dom.spec.whatwg.org/#dom-document-createattribute
+func (*Document) CreateAttribute(localName string) spec.Attr {
+	if localName == "" || strings.ContainsFunc(localName, isInvalidAttributeNameRune) {
+		panic("dom: invalid character error: invalid attribute name " + localName)
+	}
+	return newAttr(nil, html.Attribute{Key: strings.ToLower(localName)})
+}
+
+func isInvalidAttributeNameRune(r rune) bool {
+	return isASCIIWhitespace(r) || r == 0 || r == '/' || r == '>' || r == '='
+}
+
+// ImportNode returns a copy of node that can be inserted into the document.
+// It panics when node is a document. A shallow import of a DocumentFragment is an empty fragment
+// because DocumentFragment.CloneNode(false) shares the nodes of the fragment.
+func (*Document) ImportNode(node spec.Node, deep bool) spec.Node {
+	if node.NodeType() == spec.NodeTypeDocument {
+		panic("dom: not supported error: a document can not be imported")
+	}
+	if _, ok := node.(*DocumentFragment); ok && !deep {
+		return NewDocumentFragment(nil)
+	}
+	return node.CloneNode(deep)
+}
+
+// AdoptNode removes node from its parent or, for an attribute, from its owner element.
+// A node is owned by the document at the root of its tree, so nothing else needs to change.
+// It panics when node is a document.
+func (*Document) AdoptNode(node spec.Node) spec.Node {
+	switch n := node.(type) {
+	case *Document:
+		panic("dom: not supported error: a document can not be adopted")
+	case *DocumentFragment:
+	case *Attr:
+		if n.index() >= 0 {
+			removeAttributeNode(n.element, n)
+		}
+	default:
+		remove(domNodeToHTMLNode(node))
+	}
+	return node
+}
+
+func (*Document) CreateTreeWalker(root spec.Node, whatToShow spec.WhatToShow, filter spec.NodeFilter) spec.TreeWalker {
+	return newTreeWalker(root, whatToShow, filter)
+}
+
+// CreateNodeIterator returns an iterator that is updated when nodes are removed through this package.
+func (*Document) CreateNodeIterator(root spec.Node, whatToShow spec.WhatToShow, filter spec.NodeFilter) spec.NodeIterator {
+	return newNodeIterator(root, whatToShow, filter)
+}
+
+// CreateRange returns a range that is updated when the tree is mutated through this package.
+func (d *Document) CreateRange() spec.Range {
+	return newLiveRange(boundaryPoint{node: d.node}, boundaryPoint{node: d.node})
+}
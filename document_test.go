@@ -1,8 +1,11 @@
 package dom
 
 import (
+	"reflect"
+	"slices"
 	"strings"
 	"testing"
+	"weak"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -111,6 +114,36 @@ func TestDocument_GetElementsByTagName(t *testing.T) {
 	assert.Equal(t, elements.Length(), 2)
 }
 
+func TestLiveElements_cache(t *testing.T) {
+	// language=html
+	const textHTML = `<!DOCTYPE html><html><head></head><body><span></span><span></span></body></html>`
+	document, _ := parseDocument(t, textHTML, "")
+	other, _ := parseDocument(t, textHTML, "")
+	calls := 0
+	elements := newLiveElements(document.node, func(n *html.Node) bool {
+		calls++
+		return isHTMLElement(n, "span")
+	})
+
+	for i := range elements.Length() {
+		require.NotNil(t, elements.Item(i))
+	}
+	walked := calls
+	assert.Equal(t, 2, elements.Length())
+	assert.Equal(t, walked, calls, "the tree is walked once")
+
+	other.Body().Append(other.CreateElement("span"))
+	assert.Equal(t, 2, elements.Length())
+	assert.Equal(t, walked, calls, "changes to another tree do not invalidate the collection")
+
+	document.Body().Append(document.CreateElement("span"))
+	assert.Equal(t, 3, elements.Length())
+	assert.Greater(t, calls, walked)
+
+	elements.Item(0).Remove()
+	assert.Equal(t, 2, elements.Length())
+}
+
 func TestDocument_GetElementsByClassName(t *testing.T) {
 	t.Run("nothing found", func(t *testing.T) {
 		// language=html
@@ -125,7 +158,7 @@ func TestDocument_GetElementsByClassName(t *testing.T) {
 		require.NoError(t, err)
 		document := &Document{node: parsedDocument}
 		result := document.GetElementsByClassName("nothing-has-this-class")
-		require.Nil(t, result)
+		require.Zero(t, result.Length())
 	})
 
 	t.Run("element found", func(t *testing.T) {
@@ -394,3 +427,229 @@ func TestDocument_CreateTextNode(t *testing.T) {
 
 	assert.Equal(t, exp, got)
 }
+
+func TestDocument_CreateComment(t *testing.T) {
+	// language=html
+	parsedDocument, err := html.Parse(strings.NewReader(`<!DOCTYPE html><html lang='us-en'><head><title></title></head><body><!--peach--></body</html>`))
+	require.NoError(t, err)
+	exp := parsedDocument.FirstChild.NextSibling.LastChild.FirstChild
+	exp.Parent = nil
+
+	var document *Document
+	got := document.CreateComment("peach").(*Comment).node
+
+	assert.Equal(t, exp, got)
+}
+
+func TestDocument_CreateDocumentFragment(t *testing.T) {
+	var document *Document
+	fragment := document.CreateDocumentFragment()
+
+	assert.Equal(t, spec.NodeTypeDocumentFragment, fragment.NodeType())
+	assert.Equal(t, 0, fragment.ChildElementCount())
+
+	fragment.Append(document.CreateElement("p"), document.CreateTextNode("peach"))
+	assert.Equal(t, `<p></p>peach`, fragment.(*DocumentFragment).String())
+}
+
+func TestDocument_CreateAttribute(t *testing.T) {
+	var document *Document
+
+	attr := document.CreateAttribute("Data-Fruit")
+	assert.Equal(t, "data-fruit", attr.Name())
+	assert.Equal(t, "", attr.Value())
+	assert.Nil(t, attr.OwnerElement())
+
+	attr.SetValue("peach")
+	el := document.CreateElement("div")
+	assert.Nil(t, el.SetAttributeNode(attr))
+	assert.Equal(t, "peach", el.GetAttribute("data-fruit"))
+	assert.True(t, attr.OwnerElement().IsSameNode(el))
+
+	for _, name := range []string{"", "a b", "a/b", "a>b", "a=b"} {
+		assert.Panics(t, func() { document.CreateAttribute(name) }, name)
+	}
+}
+
+func TestDocument_ImportNode(t *testing.T) {
+	// language=html
+	response, _ := parseDocument(t, `<!DOCTYPE html><html><head></head><body><ul><li>peach</li></ul></body></html>`, "")
+	// language=html
+	scratch, _ := parseDocument(t, `<!DOCTYPE html><html><head></head><body></body></html>`, "")
+
+	list := response.QuerySelector("ul")
+	t.Run("deep", func(t *testing.T) {
+		imported := scratch.ImportNode(list, true).(spec.Element)
+		assert.False(t, imported.IsSameNode(list))
+		assert.Nil(t, imported.ParentNode())
+		assert.Equal(t, `<ul><li>peach</li></ul>`, imported.OuterHTML())
+		assert.True(t, list.IsConnected())
+
+		scratch.Body().Append(imported)
+		assert.True(t, imported.OwnerDocument().IsSameNode(scratch))
+	})
+	t.Run("shallow", func(t *testing.T) {
+		imported := scratch.ImportNode(list, false).(spec.Element)
+		assert.Equal(t, `<ul></ul>`, imported.OuterHTML())
+	})
+	t.Run("fragment", func(t *testing.T) {
+		fragment := scratch.CreateDocumentFragment()
+		fragment.Append(scratch.CreateElement("p"))
+		imported := scratch.ImportNode(fragment, true).(spec.DocumentFragment)
+		assert.False(t, imported.IsSameNode(fragment))
+		assert.Equal(t, 1, imported.ChildElementCount())
+
+		shallow := scratch.ImportNode(fragment, false).(spec.DocumentFragment)
+		assert.False(t, shallow.IsSameNode(fragment))
+		assert.Zero(t, shallow.ChildElementCount())
+		assert.Equal(t, 1, fragment.ChildElementCount())
+	})
+	t.Run("document", func(t *testing.T) {
+		assert.Panics(t, func() { scratch.ImportNode(response, true) })
+	})
+}
+
+func TestDocument_AdoptNode(t *testing.T) {
+	// language=html
+	response, _ := parseDocument(t, `<!DOCTYPE html><html><head></head><body><ul id="list" class="fruit"><li>peach</li></ul></body></html>`, "")
+	// language=html
+	scratch, _ := parseDocument(t, `<!DOCTYPE html><html><head></head><body></body></html>`, "")
+
+	t.Run("element", func(t *testing.T) {
+		list := response.QuerySelector("ul")
+		adopted := scratch.AdoptNode(list)
+		assert.True(t, adopted.IsSameNode(list))
+		assert.Nil(t, list.ParentNode())
+		assert.Nil(t, response.QuerySelector("ul"))
+
+		scratch.Body().Append(adopted)
+		assert.True(t, list.OwnerDocument().IsSameNode(scratch))
+		assert.Equal(t, `<ul id="list" class="fruit"><li>peach</li></ul>`, scratch.Body().InnerHTML())
+	})
+	t.Run("attribute", func(t *testing.T) {
+		list := scratch.QuerySelector("ul")
+		attr := list.GetAttributeNode("class")
+		assert.True(t, scratch.AdoptNode(attr).IsSameNode(attr))
+		assert.Nil(t, attr.OwnerElement())
+		assert.False(t, list.HasAttribute("class"))
+		assert.Equal(t, "fruit", attr.Value())
+	})
+	t.Run("document", func(t *testing.T) {
+		assert.Panics(t, func() { scratch.AdoptNode(response) })
+	})
+}
+
+func TestDocument_DocumentElement(t *testing.T) {
+	// language=html
+	document, _ := parseDocument(t, `<!DOCTYPE html><html lang="en"><head></head><body></body></html>`, "")
+	root := document.DocumentElement()
+	require.NotNil(t, root)
+	assert.Equal(t, "HTML", root.TagName())
+	assert.Equal(t, "HEAD", document.Head().TagName())
+	assert.Equal(t, "BODY", document.Body().TagName())
+
+	empty := &Document{node: &html.Node{Type: html.DocumentNode}}
+	assert.Nil(t, empty.DocumentElement())
+	assert.Nil(t, empty.Head())
+	assert.Nil(t, empty.Body())
+}
+
+func TestDocument_Title(t *testing.T) {
+	t.Run("whitespace", func(t *testing.T) {
+		// language=html
+		document, _ := parseDocument(t, "<!DOCTYPE html><html><head><title>\n  Peach \t Pie\n</title></head><body></body></html>", "")
+		assert.Equal(t, "Peach Pie", document.Title())
+	})
+	t.Run("first title", func(t *testing.T) {
+		// language=html
+		document, _ := parseDocument(t, `<!DOCTYPE html><html><head><title>Peach</title></head><body><svg><title>Icon</title></svg></body></html>`, "")
+		assert.Equal(t, "Peach", document.Title())
+	})
+	t.Run("missing", func(t *testing.T) {
+		// language=html
+		document, _ := parseDocument(t, `<!DOCTYPE html><html><head></head><body></body></html>`, "")
+		assert.Equal(t, "", document.Title())
+	})
+	t.Run("set", func(t *testing.T) {
+		// language=html
+		document, _ := parseDocument(t, `<!DOCTYPE html><html><head><title>Peach <b>x</b></title></head><body></body></html>`, "")
+		document.SetTitle("Plum")
+		assert.Equal(t, "Plum", document.Title())
+		assert.Equal(t, `<title>Plum</title>`, document.Head().InnerHTML())
+	})
+	t.Run("set without title element", func(t *testing.T) {
+		// language=html
+		document, _ := parseDocument(t, `<!DOCTYPE html><html><head><meta charset="utf-8"></head><body></body></html>`, "")
+		document.SetTitle("Plum")
+		assert.Equal(t, "Plum", document.Title())
+		assert.Equal(t, `<meta charset="utf-8"/><title>Plum</title>`, document.Head().InnerHTML())
+	})
+	t.Run("set without head", func(t *testing.T) {
+		document := &Document{node: &html.Node{Type: html.DocumentNode}}
+		assert.NotPanics(t, func() { document.SetTitle("Plum") })
+		assert.Equal(t, "", document.Title())
+	})
+}
+
+func TestDocument_collections(t *testing.T) {
+	// language=html
+	document, _ := parseDocument(t, `<!DOCTYPE html><html><head><script src="app.js"></script></head><body>
+<form id="search"><input name="q"></form>
+<form name="login"></form>
+<a href="/">home</a><a name="anchor"></a>
+<map><area href="/area"></map>
+<img src="a.png"><img src="b.png">
+<embed src="movie.swf">
+<svg><script></script><a href="/svg"></a></svg>
+</body></html>`, "")
+
+	forms := document.Forms()
+	assert.Equal(t, 2, forms.Length())
+	assert.Equal(t, "search", forms.Item(0).ID())
+	assert.NotNil(t, forms.NamedItem("login"))
+	assert.Nil(t, forms.Item(2))
+
+	links := document.Links()
+	assert.Equal(t, 2, links.Length())
+	assert.Equal(t, "/", links.Item(0).GetAttribute("href"))
+	assert.Equal(t, "/area", links.Item(1).GetAttribute("href"))
+
+	assert.Equal(t, 2, document.Images().Length())
+	assert.Equal(t, 1, document.Scripts().Length())
+	assert.Equal(t, 1, document.Embeds().Length())
+
+	t.Run("live", func(t *testing.T) {
+		document.Body().Append(document.CreateElement("form"))
+		assert.Equal(t, 3, forms.Length())
+		forms.Item(0).Remove()
+		assert.Equal(t, 2, forms.Length())
+		assert.Equal(t, "login", forms.Item(0).GetAttribute("name"))
+	})
+}
+
+func TestDocument_GetElementById_index(t *testing.T) {
+	// language=html
+	const textHTML = `<!DOCTYPE html><html><head></head><body><p id="p">text</p></body></html>`
+	first, _ := parseDocument(t, textHTML, "")
+	second, _ := parseDocument(t, textHTML, "")
+	require.NotNil(t, first.GetElementById("p"))
+
+	documentIDIndexes.Lock()
+	index := documentIDIndexes.indexes[weak.Make(first.node)]
+	documentIDIndexes.Unlock()
+	require.NotNil(t, index)
+	ids, generations := index.ids, slices.Clone(index.generations)
+
+	t.Run("another document changes", func(t *testing.T) {
+		second.Body().Append(second.CreateElement("div"))
+		second.GetElementById("p").SetAttribute("id", "q")
+		require.NotNil(t, first.GetElementById("p"))
+		assert.Equal(t, generations, index.generations)
+		assert.Equal(t, reflect.ValueOf(ids).Pointer(), reflect.ValueOf(index.ids).Pointer(), "index is not rebuilt")
+	})
+	t.Run("the document changes", func(t *testing.T) {
+		first.Body().Append(first.CreateElement("div"))
+		require.NotNil(t, first.GetElementById("p"))
+		assert.NotEqual(t, generations, index.generations)
+	})
+}
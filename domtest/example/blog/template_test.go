@@ -73,6 +73,7 @@ func TestCase(t *testing.T) {
 				el := fragment.FirstElementChild()
 				require.Equal(t, "lemon", el.TextContent())
 				require.Equal(t, "*errors.errorString", el.GetAttribute("data-type"))
+				require.Equal(t, "darkred", el.Style().GetPropertyValue("color"))
 			}),
 		},
 		{
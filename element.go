@@ -24,18 +24,23 @@ func (e *Element) QuerySelectorSequence(query string) iter.Seq[spec.Element] {
 
 // NewNode
 
-func (e *Element) NodeType() spec.NodeType         { return nodeType(e.node.Type) }
-func (e *Element) IsConnected() bool               { return isConnected(e.node) }
-func (e *Element) OwnerDocument() spec.Document    { return ownerDocument(e.node) }
-func (e *Element) ParentNode() spec.Node           { return parentNode(e.node) }
-func (e *Element) ParentElement() spec.Element     { return parentElement(e.node) }
-func (e *Element) PreviousSibling() spec.ChildNode { return previousSibling(e.node) }
-func (e *Element) NextSibling() spec.ChildNode     { return nextSibling(e.node) }
-func (e *Element) TextContent() string             { return textContent(e.node) }
-func (e *Element) CloneNode(deep bool) spec.Node   { return NewNode(cloneNode(e.node, deep)) }
-func (e *Element) IsSameNode(other spec.Node) bool { return isSameNode(e.node, other) }
+func (e *Element) NodeType() spec.NodeType          { return nodeType(e.node.Type) }
+func (e *Element) IsConnected() bool                { return isConnected(e.node) }
+func (e *Element) OwnerDocument() spec.Document     { return ownerDocument(e.node) }
+func (e *Element) ParentNode() spec.Node            { return parentNode(e.node) }
+func (e *Element) ParentElement() spec.Element      { return parentElement(e.node) }
+func (e *Element) PreviousSibling() spec.ChildNode  { return previousSibling(e.node) }
+func (e *Element) NextSibling() spec.ChildNode      { return nextSibling(e.node) }
+func (e *Element) Before(nodes ...spec.Node)        { before(e.node, nodes) }
+func (e *Element) After(nodes ...spec.Node)         { after(e.node, nodes) }
+func (e *Element) ReplaceWith(nodes ...spec.Node)   { replaceWith(e.node, nodes) }
+func (e *Element) Remove()                          { remove(e.node) }
+func (e *Element) TextContent() string              { return textContent(e.node) }
+func (e *Element) CloneNode(deep bool) spec.Node    { return NewNode(cloneNode(e.node, deep)) }
+func (e *Element) IsSameNode(other spec.Node) bool  { return isSameNode(e.node, other) }
+func (e *Element) IsEqualNode(other spec.Node) bool { return isEqualNode(e, other) }
 func (e *Element) Length() int {
-	c := e.node.FirstChild
+	c := treeFirstChild(e.node)
 	result := 0
 	for c != nil {
 		result++
@@ -44,6 +49,18 @@ func (e *Element) Length() int {
 	return result
 }
 
+func (e *Element) LookupPrefix(namespace string) string {
+	return lookupPrefix(e.node, namespace)
+}
+
+func (e *Element) LookupNamespaceURI(prefix string) string {
+	return locateNamespace(e.node, prefix)
+}
+
+func (e *Element) IsDefaultNamespace(namespace string) bool {
+	return isDefaultNamespace(e.node, namespace)
+}
+
 func (e *Element) CompareDocumentPosition(other spec.Node) spec.DocumentPosition {
 	return compareDocumentPosition(e.node, other)
 }
@@ -61,6 +78,10 @@ func (e *Element) GetElementsByTagName(name string) spec.ElementCollection {
 	return getElementsByTagName(e.node, name)
 }
 
+func (e *Element) GetElementsByTagNameNS(namespace, localName string) spec.ElementCollection {
+	return getElementsByTagNameNS(e.node, namespace, localName)
+}
+
 func (e *Element) GetElementsByClassName(name string) spec.ElementCollection {
 	return getElementsByClassName(e.node, name)
 }
@@ -72,10 +93,16 @@ func (e *Element) QuerySelector(query string) spec.Element {
 func (e *Element) QuerySelectorAll(query string) spec.NodeList[spec.Element] {
 	return querySelectorAll(e.node, query, false)
 }
+func (e *Element) ClassList() spec.DOMTokenList    { return domTokenList{node: e.node, name: "class"} }
+func (e *Element) RelList() spec.DOMTokenList      { return domTokenList{node: e.node, name: "rel"} }
+func (e *Element) Dataset() spec.DOMStringMap      { return dataset{node: e.node} }
+func (e *Element) Style() spec.CSSStyleDeclaration { return style{node: e.node} }
+
 func (e *Element) Closest(selector string) spec.Element { return closest(e.node, selector) }
 func (e *Element) Matches(selector string) bool         { return matches(e.node, selector) }
 
 func (e *Element) HasChildNodes() bool                  { return hasChildNodes(e.node) }
+func (e *Element) Normalize()                           { normalize(e.node) }
 func (e *Element) ChildNodes() spec.NodeList[spec.Node] { return childNodes(e.node) }
 func (e *Element) FirstChild() spec.ChildNode           { return firstChild(e.node) }
 func (e *Element) LastChild() spec.ChildNode            { return lastChild(e.node) }
@@ -91,37 +118,25 @@ func (e *Element) RemoveChild(node spec.ChildNode) spec.ChildNode { return remov
 
 // Element
 
-func (e *Element) TagName() string                 { return strings.ToUpper(e.node.Data) }
+func (e *Element) NamespaceURI() string            { return elementNamespaceURI(e.node) }
+func (e *Element) Prefix() string                  { return elementPrefix(e.node) }
+func (e *Element) LocalName() string               { return elementLocalName(e.node) }
 func (e *Element) ID() string                      { return getAttribute(e.node, "id") }
 func (e *Element) ClassName() string               { return getAttribute(e.node, "class") }
 func (e *Element) GetAttribute(name string) string { return getAttribute(e.node, name) }
 
-func (e *Element) SetAttribute(name, value string) {
-	name = strings.ToLower(name)
-	for index, att := range e.node.Attr {
-		if att.Key == name {
-			e.node.Attr[index].Val = value
-		}
+// TagName returns the qualified name. It is upper-cased for elements in the HTML namespace.
+func (e *Element) TagName() string {
+	if isInHTMLNamespace(e.node) {
+		return strings.ToUpper(e.node.Data)
 	}
-	e.node.Attr = append(e.node.Attr, html.Attribute{
-		Key: name, Val: value,
-	})
+	return e.node.Data
 }
 
-func (e *Element) RemoveAttribute(name string) {
-	name = strings.ToLower(name)
-	filtered := e.node.Attr[:0]
-	for _, att := range e.node.Attr {
-		if att.Key == name {
-			continue
-		}
-		filtered = append(filtered, att)
-	}
-	e.node.Attr = filtered
-}
+func (e *Element) SetAttribute(name, value string) { setAttribute(e.node, name, value) }
+func (e *Element) RemoveAttribute(name string)     { removeAttribute(e.node, name) }
 
 func (e *Element) ToggleAttribute(name string) bool {
-	name = strings.ToLower(name)
 	if e.HasAttribute(name) {
 		e.RemoveAttribute(name)
 		return false
@@ -130,14 +145,56 @@ func (e *Element) ToggleAttribute(name string) bool {
 	return true
 }
 
-func (e *Element) HasAttribute(name string) bool {
-	name = strings.ToLower(name)
+// ToggleAttributeForce is based on https://dom.spec.whatwg.org/#dom-element-toggleattribute
+// The attribute is added when force is true and removed when force is false.
+func (e *Element) ToggleAttributeForce(name string, force bool) bool {
+	switch has := e.HasAttribute(name); {
+	case force && !has:
+		e.SetAttribute(name, "")
+	case !force && has:
+		e.RemoveAttribute(name)
+	}
+	return force
+}
+
+func (e *Element) HasAttribute(name string) bool { return attributeIndex(e.node, name) >= 0 }
+func (e *Element) HasAttributes() bool           { return len(e.node.Attr) > 0 }
+
+// GetAttributeNames is based on https://dom.spec.whatwg.org/#dom-element-getattributenames
+func (e *Element) GetAttributeNames() []string {
+	names := make([]string, 0, len(e.node.Attr))
 	for _, att := range e.node.Attr {
-		if att.Key == name {
-			return true
-		}
+		names = append(names, attributeQualifiedName(att))
 	}
-	return false
+	return names
+}
+
+func (e *Element) GetAttributeNS(namespace, localName string) string {
+	if i := attributeIndexNS(e.node, namespace, localName); i >= 0 {
+		return e.node.Attr[i].Val
+	}
+	return ""
+}
+
+func (e *Element) SetAttributeNS(namespace, qualifiedName, value string) {
+	setAttributeNS(e.node, namespace, qualifiedName, value)
+}
+
+func (e *Element) RemoveAttributeNS(namespace, localName string) {
+	if i := attributeIndexNS(e.node, namespace, localName); i >= 0 {
+		removeAttributeAt(e.node, i)
+	}
+}
+
+func (e *Element) HasAttributeNS(namespace, localName string) bool {
+	return attributeIndexNS(e.node, namespace, localName) >= 0
+}
+
+func (e *Element) Attributes() spec.NamedNodeMap             { return namedNodeMap{node: e.node} }
+func (e *Element) GetAttributeNode(name string) spec.Attr    { return getAttributeNode(e.node, name) }
+func (e *Element) SetAttributeNode(attr spec.Attr) spec.Attr { return setAttributeNode(e.node, attr) }
+func (e *Element) RemoveAttributeNode(attr spec.Attr) spec.Attr {
+	return removeAttributeNode(e.node, attr)
 }
 
 func (e *Element) SetInnerHTML(s string) {
@@ -145,10 +202,7 @@ func (e *Element) SetInnerHTML(s string) {
 	if err != nil {
 		panic(err)
 	}
-	clearChildren(e.node)
-	for _, n := range nodes {
-		e.node.AppendChild(n)
-	}
+	replaceAllHTMLNodes(e.node, nodes)
 }
 
 func (e *Element) InnerHTML() string {
@@ -175,14 +229,24 @@ func (e *Element) SetOuterHTML(s string) {
 	if e.node.Parent == nil {
 		panic("browser: SetOuterHTML called on an unattached node")
 	}
-	for _, node := range nodes {
-		e.node.Parent.InsertBefore(node, e.node)
-	}
-	e.node.Parent.RemoveChild(e.node)
+	replaceHTMLNode(e.node, nodes)
 }
 
 func (e *Element) OuterHTML() string { return outerHTML(e.node) }
-func (e *Element) String() string    { return e.OuterHTML() }
+
+func (e *Element) InsertAdjacentElement(position string, element spec.Element) spec.Element {
+	return insertAdjacentElement(e.node, position, element)
+}
+
+func (e *Element) InsertAdjacentText(position, data string) {
+	insertAdjacentText(e.node, position, data)
+}
+
+func (e *Element) InsertAdjacentHTML(position, text string) {
+	insertAdjacentHTML(e.node, position, text)
+}
+
+func (e *Element) String() string { return e.OuterHTML() }
 
 type siblingElements struct {
 	firstChild *html.Node
@@ -334,3 +334,123 @@ func TestElement_CompareDocumentPosition(t *testing.T) {
 		assert.Equal(t, spec.DocumentPositionDisconnected|spec.DocumentPositionImplementationSpecific, pos)
 	})
 }
+
+func TestElement_ParentElement_root(t *testing.T) {
+	// language=html
+	_, root := parseDocument(t, `<!DOCTYPE html><html lang='us-en'><head></head><body></body></html>`, "html")
+	assert.Nil(t, root.ParentElement())
+}
+
+func TestElement_GetElementsByTagName(t *testing.T) {
+	// language=html
+	parsedDocument, err := html.Parse(strings.NewReader(`<!DOCTYPE html><html><head></head><body><div id='root'><p id='a'>p</p><div><P id='b'></P></div><svg><foreignObject id='c'></foreignObject></svg></div></body></html>`))
+	require.NoError(t, err)
+	document := &Document{node: parsedDocument}
+	root := document.GetElementById("root")
+
+	t.Run("descendants only", func(t *testing.T) {
+		elements := root.GetElementsByTagName("div")
+		require.Equal(t, 1, elements.Length())
+		assert.Empty(t, elements.Item(0).ID())
+	})
+	t.Run("text is not matched", func(t *testing.T) {
+		elements := root.GetElementsByTagName("P")
+		require.Equal(t, 2, elements.Length())
+		assert.Equal(t, "a", elements.Item(0).ID())
+		assert.Equal(t, "b", elements.Item(1).ID())
+	})
+	t.Run("foreign elements match the qualified name", func(t *testing.T) {
+		assert.Equal(t, 1, root.GetElementsByTagName("foreignObject").Length())
+		assert.Zero(t, root.GetElementsByTagName("foreignobject").Length())
+	})
+	t.Run("all elements", func(t *testing.T) {
+		assert.Equal(t, 5, root.GetElementsByTagName("*").Length())
+	})
+	t.Run("live", func(t *testing.T) {
+		elements := root.GetElementsByTagName("p")
+		root.Append(document.CreateElement("p"))
+		assert.Equal(t, 3, elements.Length())
+		root.RemoveChild(root.FirstChild())
+		assert.Equal(t, 2, elements.Length())
+	})
+}
+
+func TestElement_GetElementsByTagNameNS(t *testing.T) {
+	// language=html
+	parsedDocument, err := html.Parse(strings.NewReader(`<!DOCTYPE html><html><head></head><body><div id='root'><svg><a id='svg-a'></a></svg><a id='html-a'></a></div></body></html>`))
+	require.NoError(t, err)
+	document := &Document{node: parsedDocument}
+	root := document.GetElementById("root")
+
+	for _, tt := range []struct {
+		namespace, localName string
+		expected             []string
+	}{
+		{namespace: spec.NamespaceHTML, localName: "a", expected: []string{"html-a"}},
+		{namespace: spec.NamespaceSVG, localName: "a", expected: []string{"svg-a"}},
+		{namespace: "*", localName: "a", expected: []string{"svg-a", "html-a"}},
+		{namespace: spec.NamespaceSVG, localName: "*", expected: []string{"", "svg-a"}},
+		{namespace: "", localName: "a", expected: []string{}},
+	} {
+		t.Run(tt.namespace+" "+tt.localName, func(t *testing.T) {
+			elements := root.GetElementsByTagNameNS(tt.namespace, tt.localName)
+			ids := []string{}
+			for i := 0; i < elements.Length(); i++ {
+				ids = append(ids, elements.Item(i).ID())
+			}
+			assert.Equal(t, tt.expected, ids)
+		})
+	}
+}
+
+func TestElement_GetElementsByClassName(t *testing.T) {
+	// language=html
+	parsedDocument, err := html.Parse(strings.NewReader(`<!DOCTYPE html><html><head></head><body><div id='root' class='a'><p class='a b'></p><p class='b'></p></div></body></html>`))
+	require.NoError(t, err)
+	document := &Document{node: parsedDocument}
+	root := document.GetElementById("root")
+
+	assert.Equal(t, 1, root.GetElementsByClassName("a").Length(), "the root is not included")
+	assert.Equal(t, 1, root.GetElementsByClassName(" b  a ").Length())
+	assert.Zero(t, root.GetElementsByClassName(" ").Length())
+
+	elements := root.GetElementsByClassName("b")
+	require.Equal(t, 2, elements.Length())
+	elements.Item(1).SetAttribute("class", "c")
+	assert.Equal(t, 1, elements.Length())
+}
+
+func TestElement_ToggleAttributeForce(t *testing.T) {
+	// language=html
+	parsedDocument, err := html.Parse(strings.NewReader(`<!DOCTYPE html><html><head></head><body><input id='a' disabled></body></html>`))
+	require.NoError(t, err)
+	document := &Document{node: parsedDocument}
+	input := document.GetElementById("a")
+
+	assert.True(t, input.ToggleAttributeForce("DISABLED", true))
+	assert.True(t, input.HasAttribute("disabled"))
+	assert.False(t, input.ToggleAttributeForce("disabled", false))
+	assert.False(t, input.HasAttribute("disabled"))
+	assert.False(t, input.ToggleAttributeForce("disabled", false))
+	assert.False(t, input.HasAttribute("disabled"))
+	assert.True(t, input.ToggleAttributeForce("required", true))
+	assert.Equal(t, `<input id="a" required=""/>`, input.OuterHTML())
+}
+
+func TestElement_GetAttributeNames(t *testing.T) {
+	// language=html
+	parsedDocument, err := html.Parse(strings.NewReader(`<!DOCTYPE html><html><head></head><body><p id='a' CLASS='x' data-y></p><svg><use xlink:href='#b'></use></svg><span></span></body></html>`))
+	require.NoError(t, err)
+	document := &Document{node: parsedDocument}
+
+	p := document.GetElementById("a")
+	assert.True(t, p.HasAttributes())
+	assert.Equal(t, []string{"id", "class", "data-y"}, p.GetAttributeNames())
+
+	use := document.QuerySelector("use")
+	assert.Equal(t, []string{"xlink:href"}, use.GetAttributeNames())
+
+	span := document.QuerySelector("span")
+	assert.False(t, span.HasAttributes())
+	assert.Empty(t, span.GetAttributeNames())
+}
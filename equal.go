@@ -0,0 +1,87 @@
+package dom
+
+import (
+	"golang.org/x/net/html"
+
+	"github.com/typelate/dom/spec"
+)
+
+// isEqualNode is based on https://dom.spec.whatwg.org/#concept-node-equals
+func isEqualNode(node, other spec.Node) bool {
+	if node == nil || other == nil || node.NodeType() != other.NodeType() {
+		return false
+	}
+	switch n := node.(type) {
+	case *Attr:
+		o, ok := other.(*Attr)
+		return ok && n.NamespaceURI() == o.NamespaceURI() && n.LocalName() == o.LocalName() && n.Value() == o.Value()
+	case *DocumentFragment:
+		o, ok := other.(*DocumentFragment)
+		if !ok {
+			return false
+		}
+		nodes, otherNodes := n.children(), o.children()
+		if len(nodes) != len(otherNodes) {
+			return false
+		}
+		for i := range nodes {
+			if !isEqualHTMLNode(nodes[i], otherNodes[i]) {
+				return false
+			}
+		}
+		return true
+	default:
+		return isEqualHTMLNode(domNodeToHTMLNode(node), domNodeToHTMLNode(other))
+	}
+}
+
+func isEqualHTMLNode(a, b *html.Node) bool {
+	if a == nil || b == nil || a.Type != b.Type {
+		return false
+	}
+	switch a.Type {
+	case html.ElementNode:
+		if elementNamespaceURI(a) != elementNamespaceURI(b) ||
+			elementPrefix(a) != elementPrefix(b) ||
+			elementLocalName(a) != elementLocalName(b) ||
+			!hasEqualAttributes(a, b) {
+			return false
+		}
+	case html.DoctypeNode:
+		if a.Data != b.Data || !hasEqualAttributes(a, b) {
+			return false
+		}
+	case html.TextNode, html.CommentNode, html.RawNode:
+		if a.Data != b.Data {
+			return false
+		}
+	}
+	ac, bc := a.FirstChild, b.FirstChild
+	for ; ac != nil && bc != nil; ac, bc = ac.NextSibling, bc.NextSibling {
+		if !isEqualHTMLNode(ac, bc) {
+			return false
+		}
+	}
+	return ac == nil && bc == nil
+}
+
+// hasEqualAttributes reports whether every attribute of a has an equal attribute on b regardless of order.
+func hasEqualAttributes(a, b *html.Node) bool {
+	if len(a.Attr) != len(b.Attr) {
+		return false
+	}
+	for _, att := range a.Attr {
+		namespace := attributeNamespaceURI(a, att)
+		found := false
+		for _, other := range b.Attr {
+			if other.Key == att.Key && other.Val == att.Val && attributeNamespaceURI(b, other) == namespace {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	return true
+}
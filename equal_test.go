@@ -0,0 +1,88 @@
+package dom_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/net/html"
+
+	"github.com/typelate/dom"
+	"github.com/typelate/dom/spec"
+)
+
+func parseEqualDocument(t *testing.T, input string) spec.Document {
+	t.Helper()
+	node, err := html.Parse(strings.NewReader(input))
+	require.NoError(t, err)
+	return dom.NewNode(node).(spec.Document)
+}
+
+func TestNode_IsEqualNode(t *testing.T) {
+	t.Run("attribute order", func(t *testing.T) {
+		a := parseDocumentFragment(t, `<p class="x" id="y">1</p>`)
+		b := parseDocumentFragment(t, `<p id="y" class="x">1</p>`)
+		assert.True(t, a.IsEqualNode(b))
+		assert.True(t, a.FirstElementChild().IsEqualNode(b.FirstElementChild()))
+	})
+	for _, tt := range []struct {
+		Name string
+		A, B string
+	}{
+		{Name: "attribute value", A: `<p class="x"></p>`, B: `<p class="y"></p>`},
+		{Name: "attribute count", A: `<p class="x"></p>`, B: `<p class="x" hidden></p>`},
+		{Name: "local name", A: `<p></p>`, B: `<div></div>`},
+		{Name: "namespace", A: `<title></title>`, B: `<svg><title></title></svg>`},
+		{Name: "text", A: `<p>1</p>`, B: `<p>2</p>`},
+		{Name: "comment", A: `<!--1-->`, B: `<!--2-->`},
+		{Name: "node type", A: `<!--1-->`, B: `1`},
+		{Name: "extra child", A: `<p><b></b></p>`, B: `<p><b></b><i></i></p>`},
+		{Name: "fragment length", A: `<p></p>`, B: `<p></p><p></p>`},
+	} {
+		t.Run(tt.Name, func(t *testing.T) {
+			a := parseDocumentFragment(t, tt.A)
+			b := parseDocumentFragment(t, tt.B)
+			assert.False(t, a.IsEqualNode(b))
+			assert.False(t, b.IsEqualNode(a))
+		})
+	}
+	t.Run("text and comment", func(t *testing.T) {
+		var document *dom.Document
+		assert.True(t, document.CreateTextNode("1").IsEqualNode(document.CreateTextNode("1")))
+		assert.False(t, document.CreateTextNode("1").IsEqualNode(document.CreateTextNode("2")))
+		assert.True(t, document.CreateComment("1").IsEqualNode(document.CreateComment("1")))
+		assert.False(t, document.CreateComment("1").IsEqualNode(document.CreateTextNode("1")))
+	})
+	t.Run("document and doctype", func(t *testing.T) {
+		// language=html
+		const input = `<!DOCTYPE html><html><head><title>Peach</title></head><body><p>1</p></body></html>`
+		a := parseEqualDocument(t, input)
+		b := parseEqualDocument(t, input)
+		assert.True(t, a.IsEqualNode(b))
+		assert.True(t, a.Doctype().IsEqualNode(b.Doctype()))
+		assert.True(t, a.IsEqualNode(a.CloneNode(true)))
+		assert.False(t, a.IsEqualNode(a.CloneNode(false)))
+
+		b.Body().SetInnerHTML(`<p>2</p>`)
+		assert.False(t, a.IsEqualNode(b))
+
+		legacy := parseEqualDocument(t, `<!DOCTYPE html PUBLIC "-//W3C//DTD HTML 4.01//EN" "http://www.w3.org/TR/html4/strict.dtd"><html></html>`)
+		assert.False(t, a.Doctype().IsEqualNode(legacy.Doctype()))
+	})
+	t.Run("attr", func(t *testing.T) {
+		var document *dom.Document
+		a := document.CreateAttribute("class")
+		a.SetValue("x")
+		b := document.CreateAttribute("class")
+		b.SetValue("x")
+		assert.True(t, a.IsEqualNode(b))
+		b.SetValue("y")
+		assert.False(t, a.IsEqualNode(b))
+	})
+	t.Run("nil", func(t *testing.T) {
+		a := parseDocumentFragment(t, `<p></p>`)
+		assert.False(t, a.IsEqualNode(nil))
+		assert.False(t, a.FirstElementChild().IsEqualNode(nil))
+	})
+}
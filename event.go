@@ -0,0 +1,307 @@
+package dom
+
+import (
+	"reflect"
+	"runtime"
+	"slices"
+	"sync"
+	"weak"
+
+	"golang.org/x/net/html"
+
+	"github.com/typelate/dom/spec"
+)
+
+// event is based on https://dom.spec.whatwg.org/#interface-event
+type event struct {
+	eventType     string
+	init          spec.EventInit
+	target        spec.EventTarget
+	currentTarget spec.EventTarget
+	path          []spec.EventTarget
+	phase         spec.EventPhase
+
+	dispatching              bool
+	stopPropagation          bool
+	stopImmediatePropagation bool
+	canceled                 bool
+	inPassiveListener        bool
+}
+
+var _ spec.Event = (*event)(nil)
+
+// NewEvent is based on https://dom.spec.whatwg.org/#dom-event-event
+func NewEvent(eventType string, init spec.EventInit) spec.Event {
+	return &event{eventType: eventType, init: init}
+}
+
+func (e *event) Type() string                     { return e.eventType }
+func (e *event) Target() spec.EventTarget         { return e.target }
+func (e *event) CurrentTarget() spec.EventTarget  { return e.currentTarget }
+func (e *event) EventPhase() spec.EventPhase      { return e.phase }
+func (e *event) StopPropagation()                 { e.stopPropagation = true }
+func (e *event) Bubbles() bool                    { return e.init.Bubbles }
+func (e *event) Cancelable() bool                 { return e.init.Cancelable }
+func (e *event) Composed() bool                   { return e.init.Composed }
+func (e *event) DefaultPrevented() bool           { return e.canceled }
+func (e *event) IsTrusted() bool                  { return false }
+func (e *event) ComposedPath() []spec.EventTarget { return slices.Clone(e.path) }
+
+func (e *event) StopImmediatePropagation() {
+	e.stopPropagation = true
+	e.stopImmediatePropagation = true
+}
+
+// PreventDefault is based on https://dom.spec.whatwg.org/#set-the-canceled-flag
+func (e *event) PreventDefault() {
+	if e.init.Cancelable && !e.inPassiveListener {
+		e.canceled = true
+	}
+}
+
+// eventListener is based on https://dom.spec.whatwg.org/#concept-event-listener
+type eventListener struct {
+	eventType string
+	callback  spec.EventListener
+	capture   bool
+	once      bool
+	passive   bool
+	removed   bool
+}
+
+// eventListeners is the event listener list of an event target.
+type eventListeners struct {
+	list []*eventListener
+}
+
+// add is based on https://dom.spec.whatwg.org/#add-an-event-listener
+func (listeners *eventListeners) add(eventType string, callback spec.EventListener, options spec.AddEventListenerOptions) {
+	if callback == nil || listeners.index(eventType, callback, options.Capture) >= 0 {
+		return
+	}
+	listeners.list = append(listeners.list, &eventListener{
+		eventType: eventType,
+		callback:  callback,
+		capture:   options.Capture,
+		once:      options.Once,
+		passive:   options.Passive,
+	})
+}
+
+// remove is based on https://dom.spec.whatwg.org/#remove-an-event-listener
+func (listeners *eventListeners) remove(eventType string, callback spec.EventListener, capture bool) {
+	if i := listeners.index(eventType, callback, capture); i >= 0 {
+		listeners.removeListener(listeners.list[i])
+	}
+}
+
+func (listeners *eventListeners) removeListener(listener *eventListener) {
+	listener.removed = true
+	listeners.list = slices.DeleteFunc(listeners.list, func(l *eventListener) bool { return l == listener })
+}
+
+func (listeners *eventListeners) index(eventType string, callback spec.EventListener, capture bool) int {
+	return slices.IndexFunc(listeners.list, func(listener *eventListener) bool {
+		return listener.eventType == eventType && listener.capture == capture && sameEventListener(listener.callback, callback)
+	})
+}
+
+// sameEventListener compares listeners with == when their type is comparable.
+func sameEventListener(a, b spec.EventListener) bool {
+	t := reflect.TypeOf(a)
+	return t == reflect.TypeOf(b) && t != nil && t.Comparable() && a == b
+}
+
+// nodeEventListeners holds the event listener list of each node with listeners.
+// Nodes are weak keys so that listeners do not keep a node alive.
+var nodeEventListeners = struct {
+	sync.Mutex
+	lists map[weak.Pointer[html.Node]]*eventListeners
+}{lists: make(map[weak.Pointer[html.Node]]*eventListeners)}
+
+func htmlNodeEventListeners(node *html.Node, create bool) *eventListeners {
+	nodeEventListeners.Lock()
+	defer nodeEventListeners.Unlock()
+	key := weak.Make(node)
+	listeners, ok := nodeEventListeners.lists[key]
+	if !ok && create {
+		listeners = new(eventListeners)
+		nodeEventListeners.lists[key] = listeners
+		runtime.AddCleanup(node, func(key weak.Pointer[html.Node]) {
+			nodeEventListeners.Lock()
+			defer nodeEventListeners.Unlock()
+			delete(nodeEventListeners.lists, key)
+		}, key)
+	}
+	return listeners
+}
+
+func addEventListener(node *html.Node, eventType string, listener spec.EventListener, options spec.AddEventListenerOptions) {
+	htmlNodeEventListeners(node, true).add(eventType, listener, options)
+}
+
+func removeEventListener(node *html.Node, eventType string, listener spec.EventListener, options spec.EventListenerOptions) {
+	if listeners := htmlNodeEventListeners(node, false); listeners != nil {
+		listeners.remove(eventType, listener, options.Capture)
+	}
+}
+
+func targetEventListeners(target spec.EventTarget) *eventListeners {
+	if fragment, ok := target.(*DocumentFragment); ok {
+		return fragment.eventListeners()
+	}
+	return htmlNodeEventListeners(domNodeToHTMLNode(target.(spec.Node)), false)
+}
+
+// eventPath returns the target and its ancestors. A DocumentFragment created by NewDocumentFragment is only
+// in the path when it is the target because the nodes in the fragment do not have it as their parent.
+// The path of a node in the contents of a template element ends with the content fragment.
+func eventPath(target spec.EventTarget) []spec.EventTarget {
+	path := []spec.EventTarget{target}
+	if _, ok := target.(*DocumentFragment); ok {
+		return path
+	}
+	node := domNodeToHTMLNode(target.(spec.Node))
+	for ; node.Parent != nil; node = node.Parent {
+		if isTemplateElement(node.Parent) {
+			return append(path, contentFragment(node.Parent))
+		}
+		path = append(path, NewNode(node.Parent).(spec.EventTarget))
+	}
+	return path
+}
+
+// dispatchEvent is based on https://dom.spec.whatwg.org/#concept-event-dispatch
+// Listeners for the capturing phase are invoked from the root to the target,
+// then listeners for the bubbling phase are invoked from the target to the root.
+func dispatchEvent(target spec.EventTarget, e spec.Event) bool {
+	ev, ok := e.(*event)
+	if !ok {
+		panic("dom: not supported error: DispatchEvent requires an event created by NewEvent")
+	}
+	if ev.dispatching {
+		panic("dom: invalid state error: the event is already being dispatched")
+	}
+	ev.dispatching = true
+	ev.target = target
+	ev.path = eventPath(target)
+	for i := len(ev.path) - 1; i >= 0; i-- {
+		ev.phase = spec.EventPhaseCapturing
+		if i == 0 {
+			ev.phase = spec.EventPhaseAtTarget
+		}
+		ev.invoke(ev.path[i], true)
+	}
+	for i, item := range ev.path {
+		if i == 0 {
+			ev.phase = spec.EventPhaseAtTarget
+		} else if !ev.init.Bubbles {
+			continue
+		} else {
+			ev.phase = spec.EventPhaseBubbling
+		}
+		ev.invoke(item, false)
+	}
+	ev.phase = spec.EventPhaseNone
+	ev.currentTarget = nil
+	ev.path = nil
+	ev.dispatching = false
+	ev.stopPropagation = false
+	ev.stopImmediatePropagation = false
+	return !ev.canceled
+}
+
+// invoke is based on https://dom.spec.whatwg.org/#concept-event-listener-invoke
+func (e *event) invoke(target spec.EventTarget, capturing bool) {
+	if e.stopPropagation {
+		return
+	}
+	e.currentTarget = target
+	listeners := targetEventListeners(target)
+	if listeners == nil {
+		return
+	}
+	for _, listener := range slices.Clone(listeners.list) {
+		if listener.removed || listener.eventType != e.eventType || listener.capture != capturing {
+			continue
+		}
+		if listener.once {
+			listeners.removeListener(listener)
+		}
+		e.inPassiveListener = listener.passive
+		listener.callback.HandleEvent(e)
+		e.inPassiveListener = false
+		if e.stopImmediatePropagation {
+			return
+		}
+	}
+}
+
+func (e *Element) AddEventListener(eventType string, listener spec.EventListener, options spec.AddEventListenerOptions) {
+	addEventListener(e.node, eventType, listener, options)
+}
+
+func (e *Element) RemoveEventListener(eventType string, listener spec.EventListener, options spec.EventListenerOptions) {
+	removeEventListener(e.node, eventType, listener, options)
+}
+
+func (e *Element) DispatchEvent(event spec.Event) bool { return dispatchEvent(e, event) }
+
+func (t *Text) AddEventListener(eventType string, listener spec.EventListener, options spec.AddEventListenerOptions) {
+	addEventListener(t.node, eventType, listener, options)
+}
+
+func (t *Text) RemoveEventListener(eventType string, listener spec.EventListener, options spec.EventListenerOptions) {
+	removeEventListener(t.node, eventType, listener, options)
+}
+
+func (t *Text) DispatchEvent(event spec.Event) bool { return dispatchEvent(t, event) }
+
+func (c *Comment) AddEventListener(eventType string, listener spec.EventListener, options spec.AddEventListenerOptions) {
+	addEventListener(c.node, eventType, listener, options)
+}
+
+func (c *Comment) RemoveEventListener(eventType string, listener spec.EventListener, options spec.EventListenerOptions) {
+	removeEventListener(c.node, eventType, listener, options)
+}
+
+func (c *Comment) DispatchEvent(event spec.Event) bool { return dispatchEvent(c, event) }
+
+func (d *DocumentType) AddEventListener(eventType string, listener spec.EventListener, options spec.AddEventListenerOptions) {
+	addEventListener(d.node, eventType, listener, options)
+}
+
+func (d *DocumentType) RemoveEventListener(eventType string, listener spec.EventListener, options spec.EventListenerOptions) {
+	removeEventListener(d.node, eventType, listener, options)
+}
+
+func (d *DocumentType) DispatchEvent(event spec.Event) bool { return dispatchEvent(d, event) }
+
+func (d *Document) AddEventListener(eventType string, listener spec.EventListener, options spec.AddEventListenerOptions) {
+	addEventListener(d.node, eventType, listener, options)
+}
+
+func (d *Document) RemoveEventListener(eventType string, listener spec.EventListener, options spec.EventListenerOptions) {
+	removeEventListener(d.node, eventType, listener, options)
+}
+
+func (d *Document) DispatchEvent(event spec.Event) bool { return dispatchEvent(d, event) }
+
+func (d *DocumentFragment) AddEventListener(eventType string, listener spec.EventListener, options spec.AddEventListenerOptions) {
+	d.eventListeners().add(eventType, listener, options)
+}
+
+func (d *DocumentFragment) RemoveEventListener(eventType string, listener spec.EventListener, options spec.EventListenerOptions) {
+	d.eventListeners().remove(eventType, listener, options.Capture)
+}
+
+func (d *DocumentFragment) DispatchEvent(event spec.Event) bool { return dispatchEvent(d, event) }
+
+// eventListeners returns the listeners of the fragment. The listeners of a template element's
+// content are kept with the template so that they are not lost with the fragment value.
+func (d *DocumentFragment) eventListeners() *eventListeners {
+	if d.template != nil {
+		return templateContentListeners(d.template)
+	}
+	return &d.listeners
+}
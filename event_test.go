@@ -0,0 +1,224 @@
+package dom_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/typelate/dom"
+	"github.com/typelate/dom/spec"
+)
+
+// recordListener returns a listener that appends the name and event phase to log.
+func recordListener(log *[]string, name string) spec.EventListener {
+	fn := spec.EventListenerFunc(func(event spec.Event) {
+		*log = append(*log, fmt.Sprintf("%s:%d", name, event.EventPhase()))
+	})
+	return &fn
+}
+
+func listenerFunc(fn func(event spec.Event)) spec.EventListener {
+	listener := spec.EventListenerFunc(fn)
+	return &listener
+}
+
+func TestEventTarget_DispatchEvent(t *testing.T) {
+	t.Run("capture and bubble", func(t *testing.T) {
+		document, root := parseMutationDocument(t, `<p><span>text</span></p>`)
+		span := root.QuerySelector("span")
+		var log []string
+		for _, target := range []struct {
+			name   string
+			target spec.EventTarget
+		}{
+			{"document", document},
+			{"root", root},
+			{"span", span},
+		} {
+			target.target.AddEventListener("x", recordListener(&log, target.name+"-capture"), spec.AddEventListenerOptions{Capture: true})
+			target.target.AddEventListener("x", recordListener(&log, target.name), spec.AddEventListenerOptions{})
+		}
+
+		assert.True(t, span.DispatchEvent(dom.NewEvent("x", spec.EventInit{Bubbles: true})))
+		assert.Equal(t, []string{
+			"document-capture:1", "root-capture:1", "span-capture:2",
+			"span:2", "root:3", "document:3",
+		}, log)
+	})
+	t.Run("does not bubble", func(t *testing.T) {
+		_, root := parseMutationDocument(t, `<p></p>`)
+		p := root.FirstElementChild()
+		var log []string
+		root.AddEventListener("x", recordListener(&log, "root-capture"), spec.AddEventListenerOptions{Capture: true})
+		root.AddEventListener("x", recordListener(&log, "root"), spec.AddEventListenerOptions{})
+		p.AddEventListener("x", recordListener(&log, "p"), spec.AddEventListenerOptions{})
+
+		p.DispatchEvent(dom.NewEvent("x", spec.EventInit{}))
+		assert.Equal(t, []string{"root-capture:1", "p:2"}, log)
+	})
+	t.Run("other event types are ignored", func(t *testing.T) {
+		_, root := parseMutationDocument(t, ``)
+		var log []string
+		root.AddEventListener("x", recordListener(&log, "root"), spec.AddEventListenerOptions{})
+		root.DispatchEvent(dom.NewEvent("y", spec.EventInit{}))
+		assert.Empty(t, log)
+	})
+	t.Run("event state", func(t *testing.T) {
+		document, root := parseMutationDocument(t, `<p></p>`)
+		p := root.FirstElementChild()
+		event := dom.NewEvent("x", spec.EventInit{Bubbles: true})
+		var path []spec.EventTarget
+		root.AddEventListener("x", listenerFunc(func(e spec.Event) {
+			assert.Same(t, event, e)
+			assert.True(t, e.Target().(spec.Node).IsSameNode(p))
+			assert.True(t, e.CurrentTarget().(spec.Node).IsSameNode(root))
+			path = e.ComposedPath()
+		}), spec.AddEventListenerOptions{})
+
+		p.DispatchEvent(event)
+		require.Len(t, path, 5)
+		assert.True(t, path[0].(spec.Node).IsSameNode(p))
+		assert.True(t, path[1].(spec.Node).IsSameNode(root))
+		assert.True(t, path[4].(spec.Node).IsSameNode(document))
+		assert.Equal(t, spec.EventPhaseNone, event.EventPhase())
+		assert.Nil(t, event.CurrentTarget())
+		assert.Empty(t, event.ComposedPath())
+		assert.True(t, event.Target().(spec.Node).IsSameNode(p))
+	})
+	t.Run("text target", func(t *testing.T) {
+		_, root := parseMutationDocument(t, `text`)
+		var log []string
+		root.AddEventListener("x", recordListener(&log, "root"), spec.AddEventListenerOptions{})
+		root.FirstChild().(spec.Text).DispatchEvent(dom.NewEvent("x", spec.EventInit{Bubbles: true}))
+		assert.Equal(t, []string{"root:3"}, log)
+	})
+	t.Run("fragment", func(t *testing.T) {
+		document, _ := parseMutationDocument(t, ``)
+		fragment := document.CreateDocumentFragment()
+		var log []string
+		fragment.AddEventListener("x", recordListener(&log, "fragment"), spec.AddEventListenerOptions{})
+		assert.True(t, fragment.DispatchEvent(dom.NewEvent("x", spec.EventInit{Bubbles: true})))
+		assert.Equal(t, []string{"fragment:2"}, log)
+	})
+	t.Run("already dispatching", func(t *testing.T) {
+		_, root := parseMutationDocument(t, ``)
+		event := dom.NewEvent("x", spec.EventInit{})
+		root.AddEventListener("x", listenerFunc(func(e spec.Event) {
+			assert.Panics(t, func() { root.DispatchEvent(e) })
+		}), spec.AddEventListenerOptions{})
+		root.DispatchEvent(event)
+		assert.NotPanics(t, func() { root.DispatchEvent(event) })
+	})
+}
+
+func TestEventTarget_propagation(t *testing.T) {
+	t.Run("StopPropagation", func(t *testing.T) {
+		_, root := parseMutationDocument(t, `<p></p>`)
+		p := root.FirstElementChild()
+		var log []string
+		p.AddEventListener("x", listenerFunc(spec.Event.StopPropagation), spec.AddEventListenerOptions{})
+		p.AddEventListener("x", recordListener(&log, "p"), spec.AddEventListenerOptions{})
+		root.AddEventListener("x", recordListener(&log, "root"), spec.AddEventListenerOptions{})
+
+		event := dom.NewEvent("x", spec.EventInit{Bubbles: true})
+		p.DispatchEvent(event)
+		assert.Equal(t, []string{"p:2"}, log)
+
+		log = nil
+		root.DispatchEvent(event)
+		assert.Equal(t, []string{"root:2"}, log, "the flag is reset after dispatch")
+	})
+	t.Run("StopImmediatePropagation", func(t *testing.T) {
+		_, root := parseMutationDocument(t, `<p></p>`)
+		p := root.FirstElementChild()
+		var log []string
+		p.AddEventListener("x", listenerFunc(spec.Event.StopImmediatePropagation), spec.AddEventListenerOptions{})
+		p.AddEventListener("x", recordListener(&log, "p"), spec.AddEventListenerOptions{})
+		root.AddEventListener("x", recordListener(&log, "root"), spec.AddEventListenerOptions{})
+
+		p.DispatchEvent(dom.NewEvent("x", spec.EventInit{Bubbles: true}))
+		assert.Empty(t, log)
+	})
+}
+
+func TestEventTarget_PreventDefault(t *testing.T) {
+	for _, tt := range []struct {
+		name     string
+		init     spec.EventInit
+		options  spec.AddEventListenerOptions
+		expected bool
+	}{
+		{name: "cancelable", init: spec.EventInit{Cancelable: true}, expected: true},
+		{name: "not cancelable", init: spec.EventInit{}, expected: false},
+		{name: "passive", init: spec.EventInit{Cancelable: true}, options: spec.AddEventListenerOptions{Passive: true}, expected: false},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			_, root := parseMutationDocument(t, ``)
+			root.AddEventListener("x", listenerFunc(spec.Event.PreventDefault), tt.options)
+			event := dom.NewEvent("x", tt.init)
+			assert.Equal(t, !tt.expected, root.DispatchEvent(event))
+			assert.Equal(t, tt.expected, event.DefaultPrevented())
+		})
+	}
+}
+
+func TestEventTarget_listeners(t *testing.T) {
+	t.Run("duplicates are ignored", func(t *testing.T) {
+		_, root := parseMutationDocument(t, ``)
+		var log []string
+		listener := recordListener(&log, "root")
+		root.AddEventListener("x", listener, spec.AddEventListenerOptions{})
+		root.AddEventListener("x", listener, spec.AddEventListenerOptions{})
+		root.AddEventListener("x", listener, spec.AddEventListenerOptions{Capture: true})
+		root.DispatchEvent(dom.NewEvent("x", spec.EventInit{}))
+		assert.Equal(t, []string{"root:2", "root:2"}, log)
+	})
+	t.Run("RemoveEventListener", func(t *testing.T) {
+		_, root := parseMutationDocument(t, ``)
+		var log []string
+		listener := recordListener(&log, "root")
+		root.AddEventListener("x", listener, spec.AddEventListenerOptions{})
+		root.RemoveEventListener("x", listener, spec.EventListenerOptions{Capture: true})
+		root.DispatchEvent(dom.NewEvent("x", spec.EventInit{}))
+		assert.Len(t, log, 1)
+
+		root.RemoveEventListener("x", listener, spec.EventListenerOptions{})
+		root.DispatchEvent(dom.NewEvent("x", spec.EventInit{}))
+		assert.Len(t, log, 1)
+	})
+	t.Run("removed during dispatch", func(t *testing.T) {
+		_, root := parseMutationDocument(t, ``)
+		var log []string
+		second := recordListener(&log, "second")
+		root.AddEventListener("x", listenerFunc(func(spec.Event) {
+			root.RemoveEventListener("x", second, spec.EventListenerOptions{})
+		}), spec.AddEventListenerOptions{})
+		root.AddEventListener("x", second, spec.AddEventListenerOptions{})
+		root.DispatchEvent(dom.NewEvent("x", spec.EventInit{}))
+		assert.Empty(t, log)
+	})
+	t.Run("added during dispatch", func(t *testing.T) {
+		_, root := parseMutationDocument(t, ``)
+		var log []string
+		root.AddEventListener("x", listenerFunc(func(spec.Event) {
+			root.AddEventListener("x", recordListener(&log, "added"), spec.AddEventListenerOptions{})
+		}), spec.AddEventListenerOptions{})
+		root.DispatchEvent(dom.NewEvent("x", spec.EventInit{}))
+		assert.Empty(t, log)
+	})
+	t.Run("Once", func(t *testing.T) {
+		_, root := parseMutationDocument(t, ``)
+		var log []string
+		root.AddEventListener("x", recordListener(&log, "root"), spec.AddEventListenerOptions{Once: true})
+		root.DispatchEvent(dom.NewEvent("x", spec.EventInit{}))
+		root.DispatchEvent(dom.NewEvent("x", spec.EventInit{}))
+		assert.Equal(t, []string{"root:2"}, log)
+	})
+	t.Run("nil listener", func(t *testing.T) {
+		_, root := parseMutationDocument(t, ``)
+		root.AddEventListener("x", nil, spec.AddEventListenerOptions{})
+		assert.True(t, root.DispatchEvent(dom.NewEvent("x", spec.EventInit{})))
+	})
+}
@@ -12,14 +12,32 @@ import (
 )
 
 type DocumentFragment struct {
-	nodes []*html.Node
+	nodes     []*html.Node
+	ids       idIndex
+	listeners eventListeners
+	// template is set when the fragment is the content of a template element.
+	// The children of the template are used in place of nodes and
+	// the listeners kept with the template are used in place of listeners.
+	template *html.Node
 }
 
 func NewDocumentFragment(nodes []*html.Node) *DocumentFragment {
 	return &DocumentFragment{nodes: nodes}
 }
 
-func (d *DocumentFragment) String() string { return outerHTML(d.nodes...) }
+// children returns the top-level nodes of the fragment.
+func (d *DocumentFragment) children() []*html.Node {
+	if d.template == nil {
+		return d.nodes
+	}
+	var nodes []*html.Node
+	for c := d.template.FirstChild; c != nil; c = c.NextSibling {
+		nodes = append(nodes, c)
+	}
+	return nodes
+}
+
+func (d *DocumentFragment) String() string { return outerHTML(d.children()...) }
 
 func (d *DocumentFragment) NodeType() spec.NodeType { return spec.NodeTypeDocumentFragment }
 
@@ -27,8 +45,9 @@ func (d *DocumentFragment) CloneNode(deep bool) spec.Node {
 	if !deep {
 		return &DocumentFragment{nodes: d.nodes}
 	}
-	df := &DocumentFragment{nodes: make([]*html.Node, len(d.nodes))}
-	for _, e := range d.nodes {
+	children := d.children()
+	df := &DocumentFragment{nodes: make([]*html.Node, 0, len(children))}
+	for _, e := range children {
 		df.nodes = append(df.nodes, cloneNode(e, deep))
 	}
 	return df
@@ -39,24 +58,27 @@ func (d *DocumentFragment) IsSameNode(other spec.Node) bool {
 	if !ok {
 		return false
 	}
-	return d == o
+	return d == o || (d.template != nil && d.template == o.template)
 }
 
+func (d *DocumentFragment) IsEqualNode(other spec.Node) bool { return isEqualNode(d, other) }
+
 func (d *DocumentFragment) CompareDocumentPosition(other spec.Node) spec.DocumentPosition {
-	return compareDocumentFragmentPosition(d.nodes, other)
+	return compareDocumentFragmentPosition(d.children(), other)
 }
 
 func (d *DocumentFragment) TextContent() string {
 	var buf bytes.Buffer
-	for _, n := range d.nodes {
+	for _, n := range d.children() {
 		recursiveTextContent(&buf, n)
 	}
 	return buf.String()
 }
 
 func (d *DocumentFragment) Children() spec.ElementCollection {
-	elementChildren := make(elementList, 0, len(d.nodes))
-	for _, n := range d.nodes {
+	nodes := d.children()
+	elementChildren := make(elementList, 0, len(nodes))
+	for _, n := range nodes {
 		if n != nil && n.Type == html.ElementNode {
 			elementChildren = append(elementChildren, n)
 		}
@@ -65,7 +87,7 @@ func (d *DocumentFragment) Children() spec.ElementCollection {
 }
 
 func (d *DocumentFragment) FirstElementChild() spec.Element {
-	for _, n := range d.nodes {
+	for _, n := range d.children() {
 		if n.Type == html.ElementNode {
 			return &Element{node: n}
 		}
@@ -74,8 +96,9 @@ func (d *DocumentFragment) FirstElementChild() spec.Element {
 }
 
 func (d *DocumentFragment) LastElementChild() spec.Element {
-	for i := range d.nodes {
-		n := d.nodes[len(d.nodes)-1-i]
+	nodes := d.children()
+	for i := range nodes {
+		n := nodes[len(nodes)-1-i]
 		if n.Type == html.ElementNode {
 			return &Element{node: n}
 		}
@@ -85,7 +108,7 @@ func (d *DocumentFragment) LastElementChild() spec.Element {
 
 func (d *DocumentFragment) ChildElementCount() int {
 	count := 0
-	for _, n := range d.nodes {
+	for _, n := range d.children() {
 		if n.Type == html.ElementNode {
 			count++
 		}
@@ -94,6 +117,10 @@ func (d *DocumentFragment) ChildElementCount() int {
 }
 
 func (d *DocumentFragment) Append(nodes ...spec.Node) {
+	if d.template != nil {
+		appendNodes(d.template, nodes...)
+		return
+	}
 	d.nodes = slices.Grow(d.nodes, len(nodes))
 	for _, node := range nodes {
 		d.nodes = append(d.nodes, domNodeToHTMLNode(node))
@@ -101,6 +128,10 @@ func (d *DocumentFragment) Append(nodes ...spec.Node) {
 }
 
 func (d *DocumentFragment) Prepend(nodes ...spec.Node) {
+	if d.template != nil {
+		prependNodes(d.template, nodes)
+		return
+	}
 	children := make([]*html.Node, 0, len(d.nodes)+len(nodes))
 	for _, node := range nodes {
 		children = append(children, domNodeToHTMLNode(node))
@@ -109,6 +140,10 @@ func (d *DocumentFragment) Prepend(nodes ...spec.Node) {
 }
 
 func (d *DocumentFragment) ReplaceChildren(nodes ...spec.Node) {
+	if d.template != nil {
+		replaceChildren(d.template, nodes)
+		return
+	}
 	list := make([]*html.Node, 0, len(nodes))
 	for _, node := range nodes {
 		list = append(list, domNodeToHTMLNode(node))
@@ -116,8 +151,36 @@ func (d *DocumentFragment) ReplaceChildren(nodes ...spec.Node) {
 	d.nodes = list
 }
 
-func (d *DocumentFragment) QuerySelector(query string) spec.Element {
+func (d *DocumentFragment) GetElementById(id string) spec.Element {
+	return htmlNodeToDomElement(d.ids.lookup(d.children(), id))
+}
+
+// Normalize is based on https://dom.spec.whatwg.org/#dom-node-normalize
+func (d *DocumentFragment) Normalize() {
+	if d.template != nil {
+		normalizeChildren(d.template)
+		return
+	}
+	nodes := d.nodes[:0]
 	for _, n := range d.nodes {
+		switch {
+		case n.Type != html.TextNode:
+			normalize(n)
+		case n.Data == "":
+			continue
+		case len(nodes) > 0 && nodes[len(nodes)-1].Type == html.TextNode:
+			rangesMergedText(nodes[len(nodes)-1], n)
+			appendData(nodes[len(nodes)-1], n.Data)
+			continue
+		}
+		nodes = append(nodes, n)
+	}
+	clear(d.nodes[len(nodes):])
+	d.nodes = nodes
+}
+
+func (d *DocumentFragment) QuerySelector(query string) spec.Element {
+	for _, n := range d.children() {
 		el := querySelector(n, query, true)
 		if el != nil {
 			return el
@@ -128,7 +191,7 @@ func (d *DocumentFragment) QuerySelector(query string) spec.Element {
 
 func (d *DocumentFragment) QuerySelectorAll(query string) spec.NodeList[spec.Element] {
 	var list nodeListHTMLElements
-	for _, n := range d.nodes {
+	for _, n := range d.children() {
 		list = append(list, querySelectorAll(n, query, true)...)
 	}
 	return slices.Clip(list)
@@ -137,7 +200,7 @@ func (d *DocumentFragment) QuerySelectorAll(query string) spec.NodeList[spec.Ele
 func (d *DocumentFragment) QuerySelectorSequence(query string) iter.Seq[spec.Element] {
 	m := cascadia.MustCompile(query)
 	return func(yield func(spec.Element) bool) {
-		for _, n := range d.nodes {
+		for _, n := range d.children() {
 			if m.Match(n) {
 				if !yield(&Element{node: n}) {
 					return
@@ -39,6 +39,7 @@ func TestDocumentFragment_CloneNode(t *testing.T) {
 		clonedFragment := clone.(*dom.DocumentFragment)
 
 		require.False(t, fragment.IsSameNode(clone))
+		require.Equal(t, fragment.String(), clonedFragment.String())
 		children := fragment.Children()
 		clonedChildren := clonedFragment.Children()
 		for i := 0; i < children.Length(); i++ {
@@ -0,0 +1,155 @@
+package dom
+
+import (
+	"runtime"
+	"slices"
+	"sync"
+	"sync/atomic"
+	"weak"
+
+	"golang.org/x/net/html"
+)
+
+// treeGenerations holds a generation for each tree root that has changed. Every mutating method
+// in this package calls mutated, which gives the tree of the changed node a new value from
+// generationCounter. Caches derived from a tree, like the id index, record the root and generation
+// they were built from and are only rebuilt when their own tree changes. Roots are weak keys.
+var treeGenerations = struct {
+	sync.Mutex
+	roots map[weak.Pointer[html.Node]]uint64
+}{roots: make(map[weak.Pointer[html.Node]]uint64)}
+
+var generationCounter atomic.Uint64
+
+// mutated must be called before the tree that contains node is changed.
+func mutated(node *html.Node) { newTreeGeneration(treeRoot(node)) }
+
+func newTreeGeneration(root *html.Node) {
+	treeGenerations.Lock()
+	defer treeGenerations.Unlock()
+	key := weak.Make(root)
+	if _, ok := treeGenerations.roots[key]; !ok {
+		runtime.AddCleanup(root, func(key weak.Pointer[html.Node]) {
+			treeGenerations.Lock()
+			defer treeGenerations.Unlock()
+			delete(treeGenerations.roots, key)
+		}, key)
+	}
+	treeGenerations.roots[key] = generationCounter.Add(1)
+}
+
+// treeGeneration returns the generation of the tree that contains node.
+func treeGeneration(node *html.Node) uint64 {
+	key := weak.Make(treeRoot(node))
+	treeGenerations.Lock()
+	defer treeGenerations.Unlock()
+	return treeGenerations.roots[key]
+}
+
+func treeRoot(node *html.Node) *html.Node {
+	for node.Parent != nil {
+		node = node.Parent
+	}
+	return node
+}
+
+// idIndex maps ids to the first element in tree order with that id.
+// It holds weak pointers so that an index does not keep its tree alive.
+type idIndex struct {
+	roots       []weak.Pointer[html.Node]
+	generations []uint64
+	ids         map[string]weak.Pointer[html.Node]
+}
+
+// lookup returns the first element with the id in the inclusive descendants of roots.
+// Mutations made directly to html.Node values are not tracked by treeGenerations,
+// so a hit is verified and the index is rebuilt when it is stale.
+func (index *idIndex) lookup(roots []*html.Node, id string) *html.Node {
+	if id == "" {
+		return nil
+	}
+	if !index.current(roots) {
+		index.build(roots)
+	}
+	p, ok := index.ids[id]
+	if !ok {
+		return nil
+	}
+	if n := p.Value(); n != nil && getAttribute(n, "id") == id && hasRoot(n, roots) {
+		return n
+	}
+	index.build(roots)
+	if p, ok := index.ids[id]; ok {
+		return p.Value()
+	}
+	return nil
+}
+
+// current reports whether the index was built from roots and their trees have not changed since.
+func (index *idIndex) current(roots []*html.Node) bool {
+	if index.ids == nil || len(roots) != len(index.roots) {
+		return false
+	}
+	for i, root := range roots {
+		if index.roots[i] != weak.Make(root) || index.generations[i] != treeGeneration(root) {
+			return false
+		}
+	}
+	return true
+}
+
+func (index *idIndex) build(roots []*html.Node) {
+	index.roots = index.roots[:0]
+	index.generations = index.generations[:0]
+	for _, root := range roots {
+		index.roots = append(index.roots, weak.Make(root))
+		index.generations = append(index.generations, treeGeneration(root))
+	}
+	index.ids = make(map[string]weak.Pointer[html.Node])
+	for _, root := range roots {
+		walkNodes(root, func(n *html.Node) bool {
+			if n.Type != html.ElementNode {
+				return false
+			}
+			if id := getAttribute(n, "id"); id != "" {
+				if _, ok := index.ids[id]; !ok {
+					index.ids[id] = weak.Make(n)
+				}
+			}
+			return false
+		})
+	}
+}
+
+func hasRoot(node *html.Node, roots []*html.Node) bool {
+	for ; node != nil; node = node.Parent {
+		if slices.Contains(roots, node) {
+			return true
+		}
+	}
+	return false
+}
+
+// documentIDIndexes holds the id index for each document node.
+// Document values are created on demand by NewNode, so the index can not be a field.
+var documentIDIndexes = struct {
+	sync.Mutex
+	indexes map[weak.Pointer[html.Node]]*idIndex
+}{indexes: make(map[weak.Pointer[html.Node]]*idIndex)}
+
+func getElementByID(document *html.Node, id string) *html.Node {
+	documentIDIndexes.Lock()
+	defer documentIDIndexes.Unlock()
+	key := weak.Make(document)
+	index, ok := documentIDIndexes.indexes[key]
+	if !ok {
+		index = new(idIndex)
+		documentIDIndexes.indexes[key] = index
+		runtime.AddCleanup(document, func(key weak.Pointer[html.Node]) {
+			documentIDIndexes.Lock()
+			defer documentIDIndexes.Unlock()
+			delete(documentIDIndexes.indexes, key)
+		}, key)
+	}
+	return index.lookup([]*html.Node{document}, id)
+}
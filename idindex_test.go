@@ -0,0 +1,102 @@
+package dom_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/net/html"
+
+	"github.com/typelate/dom"
+	"github.com/typelate/dom/spec"
+)
+
+func parseIDDocument(t *testing.T) (*html.Node, spec.Document) {
+	t.Helper()
+	// language=html
+	node, err := html.Parse(strings.NewReader(`<!DOCTYPE html><html><head></head><body><h1 id="post.title:1">Title</h1><p id="dup">first</p><div><p id="dup">second</p></div></body></html>`))
+	require.NoError(t, err)
+	return node, dom.NewNode(node).(spec.Document)
+}
+
+func TestDocument_GetElementById(t *testing.T) {
+	t.Run("escaped characters", func(t *testing.T) {
+		_, document := parseIDDocument(t)
+		el := document.GetElementById("post.title:1")
+		require.NotNil(t, el)
+		assert.Equal(t, "H1", el.TagName())
+	})
+	t.Run("first in tree order", func(t *testing.T) {
+		_, document := parseIDDocument(t)
+		el := document.GetElementById("dup")
+		require.NotNil(t, el)
+		assert.Equal(t, "first", el.TextContent())
+	})
+	t.Run("not found", func(t *testing.T) {
+		_, document := parseIDDocument(t)
+		assert.Nil(t, document.GetElementById("missing"))
+		assert.Nil(t, document.GetElementById(""))
+	})
+	t.Run("set attribute", func(t *testing.T) {
+		_, document := parseIDDocument(t)
+		el := document.GetElementById("dup")
+		el.SetAttribute("id", "changed")
+		assert.True(t, el.IsSameNode(document.GetElementById("changed")))
+		assert.Equal(t, "second", document.GetElementById("dup").TextContent())
+	})
+	t.Run("append child", func(t *testing.T) {
+		_, document := parseIDDocument(t)
+		assert.Nil(t, document.GetElementById("new"))
+		el := document.CreateElement("span")
+		el.SetAttribute("id", "new")
+		document.Body().AppendChild(el)
+		assert.True(t, el.IsSameNode(document.GetElementById("new")))
+	})
+	t.Run("prepend", func(t *testing.T) {
+		_, document := parseIDDocument(t)
+		assert.Equal(t, "first", document.GetElementById("dup").TextContent())
+		el := document.CreateElement("p")
+		el.SetAttribute("id", "dup")
+		document.Body().Prepend(el)
+		assert.True(t, el.IsSameNode(document.GetElementById("dup")))
+	})
+	t.Run("remove", func(t *testing.T) {
+		_, document := parseIDDocument(t)
+		document.GetElementById("dup").Remove()
+		assert.Equal(t, "second", document.GetElementById("dup").TextContent())
+	})
+	t.Run("set inner html", func(t *testing.T) {
+		_, document := parseIDDocument(t)
+		assert.NotNil(t, document.GetElementById("post.title:1"))
+		document.Body().SetInnerHTML(`<main id="main"></main>`)
+		assert.Nil(t, document.GetElementById("post.title:1"))
+		assert.NotNil(t, document.GetElementById("main"))
+	})
+	t.Run("html node mutation", func(t *testing.T) {
+		node, document := parseIDDocument(t)
+		h1 := document.GetElementById("post.title:1")
+		require.NotNil(t, h1)
+		body := node.LastChild.LastChild
+		body.RemoveChild(body.FirstChild)
+		assert.Nil(t, document.GetElementById("post.title:1"))
+	})
+	t.Run("detached element", func(t *testing.T) {
+		_, document := parseIDDocument(t)
+		el := document.CreateElement("div")
+		el.SetAttribute("id", "detached")
+		assert.Nil(t, document.GetElementById("detached"))
+	})
+}
+
+func TestDocumentFragment_GetElementById(t *testing.T) {
+	fragment := parseDocumentFragment(t, `<p id="a.b">1</p><div><p id="c:d">2</p></div>`)
+
+	assert.Equal(t, "1", fragment.GetElementById("a.b").TextContent())
+	assert.Equal(t, "2", fragment.GetElementById("c:d").TextContent())
+	assert.Nil(t, fragment.GetElementById("missing"))
+
+	fragment.ReplaceChildren(fragment.GetElementById("c:d"))
+	assert.Nil(t, fragment.GetElementById("a.b"))
+	assert.Equal(t, "2", fragment.GetElementById("c:d").TextContent())
+}
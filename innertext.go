@@ -0,0 +1,210 @@
+package dom
+
+import (
+	"strings"
+
+	"golang.org/x/net/html"
+	"golang.org/x/net/html/atom"
+
+	"github.com/typelate/dom/spec"
+)
+
+// InnerText and SetInnerText approximate https://html.spec.whatwg.org/multipage/dom.html#the-innertext-idl-attribute
+// There is no rendering, so the used value of display for an element is the value in the
+// user agent style sheet and the white-space property is only preserved for pre-formatted elements.
+
+var _ spec.InnerTextSetter = (*Element)(nil)
+
+// InnerText is based on https://html.spec.whatwg.org/multipage/dom.html#dom-innertext
+// When the element or one of its ancestors is not rendered the TextContent is returned.
+func (e *Element) InnerText() string {
+	for n := e.node; n != nil; n = n.Parent {
+		if !isRendered(n) {
+			return textContent(e.node)
+		}
+	}
+	var text innerText
+	for c := e.node.FirstChild; c != nil; c = c.NextSibling {
+		text.collect(c, isPreformatted(e.node))
+	}
+	return text.buf.String()
+}
+
+// SetInnerText is based on https://html.spec.whatwg.org/multipage/dom.html#set-the-inner-text-steps
+// Each line break in s is replaced with a br element.
+func (e *Element) SetInnerText(s string) {
+	replaceAllHTMLNodes(e.node, renderedTextFragment(s))
+}
+
+// renderedTextFragment is based on https://html.spec.whatwg.org/multipage/dom.html#rendered-text-fragment
+func renderedTextFragment(input string) []*html.Node {
+	var nodes []*html.Node
+	for input != "" {
+		i := strings.IndexAny(input, "\r\n")
+		if i < 0 {
+			i = len(input)
+		}
+		if i > 0 {
+			nodes = append(nodes, &html.Node{Type: html.TextNode, Data: input[:i]})
+		}
+		input = input[i:]
+		for input != "" && (input[0] == '\r' || input[0] == '\n') {
+			if strings.HasPrefix(input, "\r\n") {
+				input = input[1:]
+			}
+			input = input[1:]
+			nodes = append(nodes, &html.Node{Type: html.ElementNode, Data: "br", DataAtom: atom.Br})
+		}
+	}
+	return nodes
+}
+
+// innerText is based on https://html.spec.whatwg.org/multipage/dom.html#rendered-text-collection-steps
+// Required line breaks and collapsed white space are written when the next text is written,
+// so they are dropped at the start and end of the result and of each line.
+type innerText struct {
+	buf        strings.Builder
+	lineBreaks int
+	space      bool
+	lineStart  bool
+}
+
+func (t *innerText) collect(node *html.Node, preformatted bool) {
+	if node.Type == html.TextNode {
+		if preformatted {
+			t.write(node.Data)
+		} else {
+			t.collapse(node.Data)
+		}
+		return
+	}
+	if node.Type != html.ElementNode || !isRendered(node) {
+		return
+	}
+	if isHTMLElement(node, "br") {
+		t.space = false
+		t.write("\n")
+		return
+	}
+	lineBreaks := 0
+	switch {
+	case isHTMLElement(node, "p"):
+		lineBreaks = 2
+	case isBlockLevel(node):
+		lineBreaks = 1
+	}
+	t.requireLineBreaks(lineBreaks)
+	preformatted = preformatted || isPreformatted(node)
+	for c := node.FirstChild; c != nil; c = c.NextSibling {
+		t.collect(c, preformatted)
+	}
+	switch {
+	case isHTMLElement(node, "td", "th") && hasFollowingSibling(node, "td", "th"):
+		t.space = false
+		t.write("\t")
+	case isHTMLElement(node, "tr") && hasFollowingTableRow(node):
+		t.space = false
+		t.write("\n")
+	}
+	t.requireLineBreaks(lineBreaks)
+}
+
+// collapse writes s with each sequence of white space replaced by a single space.
+func (t *innerText) collapse(s string) {
+	for s != "" {
+		word := strings.TrimLeft(s, collapsibleSpace)
+		if len(word) < len(s) {
+			t.space = true
+		}
+		s = word
+		if i := strings.IndexAny(word, collapsibleSpace); i >= 0 {
+			word, s = word[:i], word[i:]
+		} else {
+			s = ""
+		}
+		if word != "" {
+			t.write(word)
+		}
+	}
+}
+
+const collapsibleSpace = " \t\n\r\f"
+
+func (t *innerText) write(s string) {
+	if t.lineBreaks > 0 {
+		t.buf.WriteString(strings.Repeat("\n", t.lineBreaks))
+		t.lineBreaks = 0
+		t.lineStart = true
+		t.space = false
+	}
+	if t.space && t.buf.Len() > 0 && !t.lineStart {
+		t.buf.WriteByte(' ')
+	}
+	t.space = false
+	t.buf.WriteString(s)
+	t.lineStart = strings.HasSuffix(s, "\n") || strings.HasSuffix(s, "\t")
+}
+
+func (t *innerText) requireLineBreaks(count int) {
+	if count == 0 {
+		return
+	}
+	t.space = false
+	if t.buf.Len() > 0 {
+		t.lineBreaks = max(t.lineBreaks, count)
+	}
+}
+
+// isRendered reports whether the element is displayed by the user agent style sheet
+// and does not have the hidden attribute.
+func isRendered(node *html.Node) bool {
+	if node.Type != html.ElementNode {
+		return true
+	}
+	return !isHTMLElement(node,
+		"area", "base", "datalist", "head", "link", "meta", "noembed", "noframes",
+		"param", "rp", "script", "style", "template", "title",
+	) && attributeIndex(node, "hidden") < 0
+}
+
+// isBlockLevel reports whether the user agent style sheet makes the element block-level.
+func isBlockLevel(node *html.Node) bool {
+	return isHTMLElement(node,
+		"address", "article", "aside", "blockquote", "body", "caption", "center", "dd", "details",
+		"dialog", "dir", "div", "dl", "dt", "fieldset", "figcaption", "figure", "footer", "form",
+		"h1", "h2", "h3", "h4", "h5", "h6", "header", "hgroup", "hr", "html", "legend", "li",
+		"listing", "main", "menu", "nav", "ol", "optgroup", "option", "plaintext", "pre", "search",
+		"section", "summary", "table", "ul", "xmp",
+	)
+}
+
+func isPreformatted(node *html.Node) bool {
+	return isHTMLElement(node, "pre", "textarea", "listing", "plaintext", "xmp")
+}
+
+func hasFollowingSibling(node *html.Node, localNames ...string) bool {
+	for s := node.NextSibling; s != nil; s = s.NextSibling {
+		if isHTMLElement(s, localNames...) {
+			return true
+		}
+	}
+	return false
+}
+
+// hasFollowingTableRow reports whether a row after the row node is in the same table.
+func hasFollowingTableRow(row *html.Node) bool {
+	if hasFollowingSibling(row, "tr") {
+		return true
+	}
+	section := row.Parent
+	if !isHTMLElement(section, "thead", "tbody", "tfoot") {
+		return false
+	}
+	isRow := func(n *html.Node) bool { return isHTMLElement(n, "tr") }
+	for s := section.NextSibling; s != nil; s = s.NextSibling {
+		if isHTMLElement(s, "thead", "tbody", "tfoot") && firstChildElement(s, isRow) != nil {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,57 @@
+package dom_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/typelate/dom/spec"
+)
+
+func TestElement_InnerText(t *testing.T) {
+	for _, tt := range []struct {
+		name     string
+		body     string
+		expected string
+	}{
+		{name: "text", body: `hello`, expected: "hello"},
+		{name: "collapse white space", body: "\n\t  hello \n  <b> brave </b>\n new   world  \n", expected: "hello brave new world"},
+		{name: "non-breaking space", body: "a&nbsp; b", expected: "a  b"},
+		{name: "br", body: `one<br>two <br> three`, expected: "one\ntwo\nthree"},
+		{name: "block elements", body: "<div>one</div>\n<div> two </div>three<ul><li>four</li><li>five</li></ul>", expected: "one\ntwo\nthree\nfour\nfive"},
+		{name: "paragraphs", body: "<p>one</p>\n<p>two</p><div>three</div>", expected: "one\n\ntwo\n\nthree"},
+		{name: "nested blocks", body: `<div><div><p>one</p></div></div><div>two</div>`, expected: "one\n\ntwo"},
+		{name: "table", body: "<table>\n<thead><tr><th>a</th> <th>b</th></tr></thead>\n<tbody><tr><td>1</td><td>2</td></tr></tbody></table>", expected: "a\tb\n1\t2"},
+		{name: "skipped elements", body: `a<script>b</script><style>c</style><template>d</template><span hidden>e</span>f`, expected: "af"},
+		{name: "pre", body: "<pre>  one\n  two</pre>", expected: "  one\n  two"},
+		{name: "comments", body: `a<!-- b -->c`, expected: "ac"},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			_, root := parseMutationDocument(t, tt.body)
+			assert.Equal(t, tt.expected, root.(spec.InnerTextSetter).InnerText())
+		})
+	}
+	t.Run("not rendered", func(t *testing.T) {
+		document, _ := parseMutationDocument(t, `<div hidden><p id="p"> a  b </p></div>`)
+		assert.Equal(t, " a  b ", document.GetElementById("p").(spec.InnerTextSetter).InnerText())
+	})
+}
+
+func TestElement_SetInnerText(t *testing.T) {
+	for _, tt := range []struct {
+		name     string
+		text     string
+		expected string
+	}{
+		{name: "text", text: "a < b", expected: `a &lt; b`},
+		{name: "line breaks", text: "one\ntwo\r\nthree\rfour", expected: `one<br/>two<br/>three<br/>four`},
+		{name: "consecutive line breaks", text: "\none\n\n", expected: `<br/>one<br/><br/>`},
+		{name: "empty", text: "", expected: ``},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			_, root := parseMutationDocument(t, `<p>old</p>`)
+			root.(spec.InnerTextSetter).SetInnerText(tt.text)
+			assert.Equal(t, tt.expected, root.InnerHTML())
+		})
+	}
+}
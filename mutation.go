@@ -0,0 +1,117 @@
+package dom
+
+import (
+	"slices"
+	"sync"
+	"weak"
+
+	"golang.org/x/net/html"
+)
+
+// The functions below are used for every change to the children of a node in this package.
+// They run the steps that keep live objects like NodeIterator and Range consistent with the tree
+// and queue one childList MutationRecord for each change.
+
+// insertHTMLNode is based on https://dom.spec.whatwg.org/#concept-node-insert
+// The node must not have a parent. A nil reference appends node.
+func insertHTMLNode(parent, node, reference *html.Node) {
+	insertHTMLNodes(parent, []*html.Node{node}, reference)
+}
+
+// insertHTMLNodes inserts the nodes in order before reference. The nodes must not have parents.
+func insertHTMLNodes(parent *html.Node, nodes []*html.Node, reference *html.Node) {
+	if len(nodes) == 0 {
+		return
+	}
+	previousSibling := parent.LastChild
+	if reference != nil {
+		previousSibling = reference.PrevSibling
+	}
+	for _, n := range nodes {
+		insertNode(parent, n, reference)
+	}
+	queueChildListRecord(parent, nodes, nil, previousSibling, reference)
+}
+
+// removeHTMLNode is based on https://dom.spec.whatwg.org/#concept-node-remove
+// It does nothing when node does not have a parent.
+func removeHTMLNode(node *html.Node) {
+	parent := node.Parent
+	if parent == nil {
+		return
+	}
+	previousSibling, nextSibling := node.PrevSibling, node.NextSibling
+	removeNode(node)
+	queueChildListRecord(parent, nil, []*html.Node{node}, previousSibling, nextSibling)
+}
+
+// replaceHTMLNode is based on https://dom.spec.whatwg.org/#concept-node-replace
+// The child must have a parent and the nodes must not have parents.
+func replaceHTMLNode(child *html.Node, nodes []*html.Node) {
+	parent := child.Parent
+	previousSibling, reference := child.PrevSibling, child.NextSibling
+	removeNode(child)
+	for _, n := range nodes {
+		insertNode(parent, n, reference)
+	}
+	queueChildListRecord(parent, nodes, []*html.Node{child}, previousSibling, reference)
+}
+
+// replaceAllHTMLNodes is based on https://dom.spec.whatwg.org/#concept-node-replace-all
+// The nodes must not have parents.
+func replaceAllHTMLNodes(parent *html.Node, nodes []*html.Node) {
+	var removed []*html.Node
+	for c := parent.FirstChild; c != nil; c = parent.FirstChild {
+		removed = append(removed, c)
+		removeNode(c)
+	}
+	for _, n := range nodes {
+		insertNode(parent, n, nil)
+	}
+	queueChildListRecord(parent, nodes, removed, nil, nil)
+}
+
+// insertNode and removeNode change the tree without queueing a MutationRecord.
+
+func insertNode(parent, node, reference *html.Node) {
+	mutated(parent)
+	parent.InsertBefore(node, reference)
+	rangesInserted(parent, node)
+}
+
+func removeNode(node *html.Node) {
+	mutated(node)
+	rangesPreRemove(node)
+	nodeIteratorsPreRemove(node)
+	mutationObserversPreRemove(node)
+	node.Parent.RemoveChild(node)
+	// The removed node is the root of a new tree. It gets a new generation so that
+	// a cache built from an earlier tree rooted at node is not mistaken for current.
+	mutated(node)
+}
+
+// liveSet holds weak pointers to live objects that are updated when the tree changes.
+// Objects that have been garbage collected are dropped.
+type liveSet[T any] struct {
+	sync.Mutex
+	list []weak.Pointer[T]
+}
+
+func (s *liveSet[T]) add(value *T) {
+	s.Lock()
+	defer s.Unlock()
+	s.list = append(s.list, weak.Make(value))
+}
+
+func (s *liveSet[T]) each(fn func(value *T)) {
+	s.Lock()
+	defer s.Unlock()
+	s.list = slices.DeleteFunc(s.list, func(p weak.Pointer[T]) bool {
+		value := p.Value()
+		if value == nil {
+			return true
+		}
+		fn(value)
+		return false
+	})
+}
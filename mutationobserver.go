@@ -0,0 +1,325 @@
+package dom
+
+import (
+	"runtime"
+	"slices"
+	"sync"
+	"weak"
+
+	"golang.org/x/net/html"
+
+	"github.com/typelate/dom/spec"
+)
+
+// mutationObserver is based on https://dom.spec.whatwg.org/#interface-mutationobserver
+type mutationObserver struct {
+	callback spec.MutationCallback
+	records  []spec.MutationRecord
+	// nodes may have a registered observer for this observer in their list
+	nodes []weak.Pointer[html.Node]
+}
+
+var (
+	_ spec.MutationObserver        = (*mutationObserver)(nil)
+	_ spec.MutationObserverFlusher = (*mutationObserver)(nil)
+)
+
+// registeredObserver is based on https://dom.spec.whatwg.org/#registered-observer
+// The source is set for transient registered observers.
+type registeredObserver struct {
+	observer *mutationObserver
+	options  spec.MutationObserverInit
+	source   *registeredObserver
+}
+
+// mutationObservers holds the registered observer list for each observed node and the
+// observers that are observing at least one node in the order they started observing.
+// Nodes are weak keys so that observing a node does not keep it alive.
+var mutationObservers = struct {
+	sync.Mutex
+	lists     map[weak.Pointer[html.Node]][]*registeredObserver
+	observers []*mutationObserver
+}{lists: make(map[weak.Pointer[html.Node]][]*registeredObserver)}
+
+// NewMutationObserver is based on https://dom.spec.whatwg.org/#dom-mutationobserver-mutationobserver
+// There is no microtask queue, so the callback is only called by Flush and FlushMutationObservers.
+// The callback may be nil when records are read with TakeRecords.
+func NewMutationObserver(callback spec.MutationCallback) spec.MutationObserver {
+	return &mutationObserver{callback: callback}
+}
+
+// FlushMutationObservers is based on https://dom.spec.whatwg.org/#notify-mutation-observers
+// It calls the callback of each observer with pending records and repeats
+// until callbacks stop making observed mutations.
+// Every observing observer in the process is notified, including observers of other documents,
+// so code that runs concurrently with other users of this package, like parallel tests, should
+// call Flush on its own observers instead.
+func FlushMutationObservers() {
+	for {
+		mutationObservers.Lock()
+		observers := slices.Clone(mutationObservers.observers)
+		mutationObservers.Unlock()
+		notified := false
+		for _, mo := range observers {
+			notified = mo.notify() || notified
+		}
+		if !notified {
+			return
+		}
+	}
+}
+
+// Flush calls the callback of the observer with its pending records and repeats
+// until the callback stops making mutations the observer is interested in.
+// Other observers are not notified.
+func (mo *mutationObserver) Flush() {
+	for mo.notify() {
+	}
+}
+
+// notify takes the records of mo and passes them to its callback.
+// It reports whether the callback was called.
+func (mo *mutationObserver) notify() bool {
+	records := mo.TakeRecords()
+	mo.removeTransientObservers()
+	if len(records) == 0 || mo.callback == nil {
+		return false
+	}
+	mo.callback(records, mo)
+	return true
+}
+
+// Observe is based on https://dom.spec.whatwg.org/#dom-mutationobserver-observe
+func (mo *mutationObserver) Observe(target spec.Node, options spec.MutationObserverInit) {
+	node := observedNode(target)
+	if options.AttributeOldValue || options.AttributeFilter != nil {
+		options.Attributes = true
+	}
+	if options.CharacterDataOldValue {
+		options.CharacterData = true
+	}
+	if !options.ChildList && !options.Attributes && !options.CharacterData {
+		panic("dom: type error: one of ChildList, Attributes, or CharacterData must be true")
+	}
+	mutationObservers.Lock()
+	defer mutationObservers.Unlock()
+	for _, registered := range mutationObservers.lists[weak.Make(node)] {
+		if registered.observer == mo && registered.source == nil {
+			mo.removeRegisteredObservers(func(transient *registeredObserver) bool { return transient.source == registered })
+			registered.options = options
+			return
+		}
+	}
+	addRegisteredObserver(node, &registeredObserver{observer: mo, options: options})
+	if !slices.Contains(mutationObservers.observers, mo) {
+		mutationObservers.observers = append(mutationObservers.observers, mo)
+	}
+}
+
+// Disconnect is based on https://dom.spec.whatwg.org/#dom-mutationobserver-disconnect
+func (mo *mutationObserver) Disconnect() {
+	mutationObservers.Lock()
+	defer mutationObservers.Unlock()
+	mo.removeRegisteredObservers(func(*registeredObserver) bool { return true })
+	mo.nodes = nil
+	mo.records = nil
+	mutationObservers.observers = slices.DeleteFunc(mutationObservers.observers, func(o *mutationObserver) bool { return o == mo })
+}
+
+// TakeRecords is based on https://dom.spec.whatwg.org/#dom-mutationobserver-takerecords
+func (mo *mutationObserver) TakeRecords() []spec.MutationRecord {
+	mutationObservers.Lock()
+	defer mutationObservers.Unlock()
+	records := mo.records
+	mo.records = nil
+	return records
+}
+
+func (mo *mutationObserver) removeTransientObservers() {
+	mutationObservers.Lock()
+	defer mutationObservers.Unlock()
+	mo.removeRegisteredObservers(func(transient *registeredObserver) bool { return transient.source != nil })
+}
+
+// removeRegisteredObservers removes the registered observers of mo that match from the lists of its nodes.
+// It must be called while holding the mutationObservers lock.
+func (mo *mutationObserver) removeRegisteredObservers(match func(registered *registeredObserver) bool) {
+	mo.nodes = slices.DeleteFunc(mo.nodes, func(key weak.Pointer[html.Node]) bool {
+		list, ok := mutationObservers.lists[key]
+		if !ok {
+			return true
+		}
+		list = slices.DeleteFunc(list, func(registered *registeredObserver) bool {
+			return registered.observer == mo && match(registered)
+		})
+		if len(list) == 0 {
+			delete(mutationObservers.lists, key)
+		} else {
+			mutationObservers.lists[key] = list
+		}
+		return !slices.ContainsFunc(list, func(registered *registeredObserver) bool { return registered.observer == mo })
+	})
+}
+
+// addRegisteredObserver must be called while holding the mutationObservers lock.
+func addRegisteredObserver(node *html.Node, registered *registeredObserver) {
+	key := weak.Make(node)
+	list, ok := mutationObservers.lists[key]
+	if !ok {
+		runtime.AddCleanup(node, func(key weak.Pointer[html.Node]) {
+			mutationObservers.Lock()
+			defer mutationObservers.Unlock()
+			delete(mutationObservers.lists, key)
+		}, key)
+	}
+	mutationObservers.lists[key] = append(list, registered)
+	if mo := registered.observer; !slices.Contains(mo.nodes, key) {
+		mo.nodes = append(mo.nodes, key)
+	}
+}
+
+func observedNode(node spec.Node) *html.Node {
+	if _, ok := node.(*DocumentFragment); ok {
+		panic("dom: not supported error: a DocumentFragment can not be observed")
+	}
+	n := domNodeToHTMLNode(node)
+	if n == nil {
+		panic("dom: not supported error: an Attr can not be observed")
+	}
+	return n
+}
+
+// mutationObserversPreRemove is based on the registered observer steps in https://dom.spec.whatwg.org/#concept-node-remove
+// Observers of the ancestors that use Subtree keep observing the removed node until the next flush.
+func mutationObserversPreRemove(node *html.Node) {
+	mutationObservers.Lock()
+	defer mutationObservers.Unlock()
+	if len(mutationObservers.lists) == 0 {
+		return
+	}
+	for ancestor := treeParent(node); ancestor != nil; ancestor = treeParent(ancestor) {
+		for _, registered := range mutationObservers.lists[weak.Make(ancestor)] {
+			if registered.options.Subtree {
+				addRegisteredObserver(node, &registeredObserver{
+					observer: registered.observer,
+					options:  registered.options,
+					source:   registered,
+				})
+			}
+		}
+	}
+}
+
+// queueMutationRecord is based on https://dom.spec.whatwg.org/#queueing-a-mutation-record
+// Each interested observer gets a copy of the record with oldValue set when its options ask for it.
+// The contents of a template element are a separate tree, so the ancestors of the template are not
+// interested in mutations to them. A change to the children of a template is a change to its content
+// fragment, which can not be observed.
+func queueMutationRecord(record mutationRecord, oldValue string) {
+	mutationObservers.Lock()
+	defer mutationObservers.Unlock()
+	if len(mutationObservers.lists) == 0 ||
+		(record.recordType == spec.MutationChildList && isTemplateElement(record.target)) {
+		return
+	}
+	type interestedObserver struct {
+		observer *mutationObserver
+		oldValue bool
+	}
+	var interested []interestedObserver
+	for node := record.target; node != nil; node = treeParent(node) {
+		for _, registered := range mutationObservers.lists[weak.Make(node)] {
+			options := registered.options
+			switch {
+			case node != record.target && !options.Subtree,
+				record.recordType == spec.MutationAttributes && !options.Attributes,
+				record.recordType == spec.MutationAttributes && options.AttributeFilter != nil &&
+					(record.attributeNamespace != "" || !slices.Contains(options.AttributeFilter, record.attributeName)),
+				record.recordType == spec.MutationCharacterData && !options.CharacterData,
+				record.recordType == spec.MutationChildList && !options.ChildList:
+				continue
+			}
+			i := slices.IndexFunc(interested, func(o interestedObserver) bool { return o.observer == registered.observer })
+			if i < 0 {
+				interested = append(interested, interestedObserver{observer: registered.observer})
+				i = len(interested) - 1
+			}
+			if (record.recordType == spec.MutationAttributes && options.AttributeOldValue) ||
+				(record.recordType == spec.MutationCharacterData && options.CharacterDataOldValue) {
+				interested[i].oldValue = true
+			}
+		}
+	}
+	for _, o := range interested {
+		r := record
+		if o.oldValue {
+			r.oldValue = oldValue
+		}
+		o.observer.records = append(o.observer.records, &r)
+	}
+}
+
+// queueChildListRecord is based on https://dom.spec.whatwg.org/#queue-a-tree-mutation-record
+func queueChildListRecord(target *html.Node, added, removed []*html.Node, previousSibling, nextSibling *html.Node) {
+	if len(added) == 0 && len(removed) == 0 {
+		return
+	}
+	queueMutationRecord(mutationRecord{
+		recordType:      spec.MutationChildList,
+		target:          target,
+		addedNodes:      slices.Clone(added),
+		removedNodes:    slices.Clone(removed),
+		previousSibling: previousSibling,
+		nextSibling:     nextSibling,
+	}, "")
+}
+
+// queueAttributeRecord queues an attributes record for a change to att on element.
+// It must be called with the value of att before the change.
+func queueAttributeRecord(element *html.Node, att html.Attribute, oldValue string) {
+	queueMutationRecord(mutationRecord{
+		recordType:         spec.MutationAttributes,
+		target:             element,
+		attributeName:      att.Key,
+		attributeNamespace: attributeNamespaceURI(element, att),
+	}, oldValue)
+}
+
+// queueCharacterDataRecord queues a characterData record for node. It must be called before node.Data is changed.
+func queueCharacterDataRecord(node *html.Node) {
+	queueMutationRecord(mutationRecord{recordType: spec.MutationCharacterData, target: node}, node.Data)
+}
+
+// mutationRecord is based on https://dom.spec.whatwg.org/#interface-mutationrecord
+type mutationRecord struct {
+	recordType                        spec.MutationRecordType
+	target                            *html.Node
+	addedNodes, removedNodes          []*html.Node
+	previousSibling, nextSibling      *html.Node
+	attributeName, attributeNamespace string
+	oldValue                          string
+}
+
+var _ spec.MutationRecord = (*mutationRecord)(nil)
+
+func (r *mutationRecord) Type() spec.MutationRecordType          { return r.recordType }
+func (r *mutationRecord) Target() spec.Node                      { return NewNode(r.target) }
+func (r *mutationRecord) AddedNodes() spec.NodeList[spec.Node]   { return nodeList(r.addedNodes) }
+func (r *mutationRecord) RemovedNodes() spec.NodeList[spec.Node] { return nodeList(r.removedNodes) }
+func (r *mutationRecord) PreviousSibling() spec.Node             { return NewNode(r.previousSibling) }
+func (r *mutationRecord) NextSibling() spec.Node                 { return NewNode(r.nextSibling) }
+func (r *mutationRecord) AttributeName() string                  { return r.attributeName }
+func (r *mutationRecord) AttributeNamespace() string             { return r.attributeNamespace }
+func (r *mutationRecord) OldValue() string                       { return r.oldValue }
+
+// nodeList is a static spec.NodeList.
+type nodeList []*html.Node
+
+func (list nodeList) Length() int { return len(list) }
+
+func (list nodeList) Item(index int) spec.Node {
+	if index < 0 || index >= len(list) {
+		return nil
+	}
+	return NewNode(list[index])
+}
@@ -0,0 +1,388 @@
+package dom_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/net/html"
+
+	"github.com/typelate/dom"
+	"github.com/typelate/dom/spec"
+)
+
+func parseMutationDocument(t *testing.T, body string) (spec.Document, spec.Element) {
+	t.Helper()
+	node, err := html.Parse(strings.NewReader(`<!DOCTYPE html><html><head></head><body><div id="root">` + body + `</div></body></html>`))
+	require.NoError(t, err)
+	document := dom.NewNode(node).(spec.Document)
+	return document, document.GetElementById("root")
+}
+
+func nodeNames(list spec.NodeList[spec.Node]) []string {
+	names := make([]string, 0, list.Length())
+	for i := 0; i < list.Length(); i++ {
+		names = append(names, nodeName(list.Item(i)))
+	}
+	return names
+}
+
+func observe(t *testing.T, target spec.Node, options spec.MutationObserverInit) spec.MutationObserver {
+	t.Helper()
+	observer := dom.NewMutationObserver(nil)
+	observer.Observe(target, options)
+	t.Cleanup(observer.Disconnect)
+	return observer
+}
+
+func TestMutationObserver_childList(t *testing.T) {
+	t.Run("AppendChild", func(t *testing.T) {
+		document, root := parseMutationDocument(t, `<p>one</p>`)
+		observer := observe(t, root, spec.MutationObserverInit{ChildList: true})
+		root.AppendChild(document.CreateElement("hr"))
+
+		records := observer.TakeRecords()
+		require.Len(t, records, 1)
+		assert.Equal(t, spec.MutationChildList, records[0].Type())
+		assert.True(t, records[0].Target().IsSameNode(root))
+		assert.Equal(t, []string{"hr"}, nodeNames(records[0].AddedNodes()))
+		assert.Empty(t, nodeNames(records[0].RemovedNodes()))
+		assert.Equal(t, "p", nodeName(records[0].PreviousSibling()))
+		assert.Nil(t, records[0].NextSibling())
+		assert.Empty(t, observer.TakeRecords())
+	})
+	t.Run("InsertBefore", func(t *testing.T) {
+		document, root := parseMutationDocument(t, `<p>one</p><p>two</p>`)
+		observer := observe(t, root, spec.MutationObserverInit{ChildList: true})
+		root.InsertBefore(document.CreateElement("hr"), root.LastChild())
+
+		records := observer.TakeRecords()
+		require.Len(t, records, 1)
+		assert.Equal(t, "#one", nodeName(records[0].PreviousSibling().(spec.Element).FirstChild()))
+		assert.Equal(t, "#two", nodeName(records[0].NextSibling().(spec.Element).FirstChild()))
+	})
+	t.Run("ReplaceChild", func(t *testing.T) {
+		document, root := parseMutationDocument(t, `<p>one</p><p>two</p>`)
+		observer := observe(t, root, spec.MutationObserverInit{ChildList: true})
+		root.ReplaceChild(document.CreateElement("hr"), root.FirstChild())
+
+		records := observer.TakeRecords()
+		require.Len(t, records, 1)
+		assert.Equal(t, []string{"hr"}, nodeNames(records[0].AddedNodes()))
+		assert.Equal(t, []string{"p"}, nodeNames(records[0].RemovedNodes()))
+		assert.Nil(t, records[0].PreviousSibling())
+		assert.Equal(t, "p", nodeName(records[0].NextSibling()))
+	})
+	t.Run("RemoveChild", func(t *testing.T) {
+		_, root := parseMutationDocument(t, `<p>one</p><p>two</p>`)
+		observer := observe(t, root, spec.MutationObserverInit{ChildList: true})
+		root.RemoveChild(root.FirstChild())
+
+		records := observer.TakeRecords()
+		require.Len(t, records, 1)
+		assert.Equal(t, []string{"p"}, nodeNames(records[0].RemovedNodes()))
+		assert.Nil(t, records[0].PreviousSibling())
+		assert.Equal(t, "p", nodeName(records[0].NextSibling()))
+	})
+	t.Run("Append and Prepend", func(t *testing.T) {
+		document, root := parseMutationDocument(t, `<p>one</p>`)
+		observer := observe(t, root, spec.MutationObserverInit{ChildList: true})
+		root.Append(document.CreateElement("a"), document.CreateTextNode("text"))
+		root.Prepend(document.CreateElement("b"))
+
+		records := observer.TakeRecords()
+		require.Len(t, records, 2)
+		assert.Equal(t, []string{"a", "#text"}, nodeNames(records[0].AddedNodes()))
+		assert.Equal(t, []string{"b"}, nodeNames(records[1].AddedNodes()))
+		assert.Nil(t, records[1].PreviousSibling())
+		assert.Equal(t, "p", nodeName(records[1].NextSibling()))
+	})
+	t.Run("ReplaceChildren", func(t *testing.T) {
+		document, root := parseMutationDocument(t, `<p>one</p><p>two</p>`)
+		observer := observe(t, root, spec.MutationObserverInit{ChildList: true})
+		root.ReplaceChildren(document.CreateElement("hr"))
+
+		records := observer.TakeRecords()
+		require.Len(t, records, 1)
+		assert.Equal(t, []string{"hr"}, nodeNames(records[0].AddedNodes()))
+		assert.Equal(t, []string{"p", "p"}, nodeNames(records[0].RemovedNodes()))
+	})
+	t.Run("SetInnerHTML", func(t *testing.T) {
+		_, root := parseMutationDocument(t, `<p>one</p>`)
+		observer := observe(t, root, spec.MutationObserverInit{ChildList: true})
+		root.SetInnerHTML(`<a></a><b></b>`)
+
+		records := observer.TakeRecords()
+		require.Len(t, records, 1)
+		assert.Equal(t, []string{"a", "b"}, nodeNames(records[0].AddedNodes()))
+		assert.Equal(t, []string{"p"}, nodeNames(records[0].RemovedNodes()))
+	})
+	t.Run("SetOuterHTML", func(t *testing.T) {
+		_, root := parseMutationDocument(t, `<p>one</p><p>two</p>`)
+		observer := observe(t, root, spec.MutationObserverInit{ChildList: true})
+		root.FirstElementChild().SetOuterHTML(`<a></a><b></b>`)
+
+		records := observer.TakeRecords()
+		require.Len(t, records, 1)
+		assert.True(t, records[0].Target().IsSameNode(root))
+		assert.Equal(t, []string{"a", "b"}, nodeNames(records[0].AddedNodes()))
+		assert.Equal(t, []string{"p"}, nodeNames(records[0].RemovedNodes()))
+		assert.Equal(t, "p", nodeName(records[0].NextSibling()))
+	})
+	t.Run("moving a node", func(t *testing.T) {
+		document, root := parseMutationDocument(t, `<p id="from"><b>bold</b></p><p id="to"></p>`)
+		observer := observe(t, root, spec.MutationObserverInit{ChildList: true, Subtree: true})
+		document.GetElementById("to").AppendChild(document.GetElementById("from").FirstChild())
+
+		records := observer.TakeRecords()
+		require.Len(t, records, 2)
+		assert.True(t, records[0].Target().IsSameNode(document.GetElementById("from")))
+		assert.Equal(t, []string{"b"}, nodeNames(records[0].RemovedNodes()))
+		assert.True(t, records[1].Target().IsSameNode(document.GetElementById("to")))
+		assert.Equal(t, []string{"b"}, nodeNames(records[1].AddedNodes()))
+	})
+	t.Run("without subtree", func(t *testing.T) {
+		document, root := parseMutationDocument(t, `<p>one</p>`)
+		observer := observe(t, root, spec.MutationObserverInit{ChildList: true})
+		root.FirstElementChild().Append(document.CreateElement("b"))
+		assert.Empty(t, observer.TakeRecords())
+	})
+	t.Run("template contents", func(t *testing.T) {
+		document, root := parseMutationDocument(t, `<template id="tmpl"><p>one</p></template>`)
+		template := document.GetElementById("tmpl").(spec.HTMLTemplateElement)
+		options := spec.MutationObserverInit{ChildList: true, Attributes: true, CharacterData: true, Subtree: true}
+		templateObserver := observe(t, template, options)
+		rootObserver := observe(t, root, options)
+
+		content := template.Content()
+		p := content.FirstElementChild()
+		p.Append(document.CreateElement("b"))
+		p.SetAttribute("class", "a")
+		p.FirstChild().(spec.Text).SetData("two")
+		content.Append(document.CreateElement("hr"))
+		p.Remove()
+		assert.Empty(t, templateObserver.TakeRecords())
+		assert.Empty(t, rootObserver.TakeRecords())
+
+		template.SetAttribute("class", "a")
+		assert.Len(t, templateObserver.TakeRecords(), 1)
+		assert.Len(t, rootObserver.TakeRecords(), 1)
+	})
+}
+
+func TestMutationObserver_attributes(t *testing.T) {
+	t.Run("old value", func(t *testing.T) {
+		_, root := parseMutationDocument(t, ``)
+		observer := observe(t, root, spec.MutationObserverInit{AttributeOldValue: true})
+		root.SetAttribute("class", "a")
+		root.SetAttribute("class", "b")
+		root.RemoveAttribute("class")
+		root.RemoveAttribute("missing")
+
+		records := observer.TakeRecords()
+		require.Len(t, records, 3)
+		for _, record := range records {
+			assert.Equal(t, spec.MutationAttributes, record.Type())
+			assert.Equal(t, "class", record.AttributeName())
+			assert.Equal(t, "", record.AttributeNamespace())
+		}
+		assert.Equal(t, "", records[0].OldValue())
+		assert.Equal(t, "a", records[1].OldValue())
+		assert.Equal(t, "b", records[2].OldValue())
+	})
+	t.Run("without old value", func(t *testing.T) {
+		_, root := parseMutationDocument(t, ``)
+		root.SetAttribute("title", "before")
+		observer := observe(t, root, spec.MutationObserverInit{Attributes: true})
+		root.SetAttribute("title", "after")
+
+		records := observer.TakeRecords()
+		require.Len(t, records, 1)
+		assert.Equal(t, "", records[0].OldValue())
+	})
+	t.Run("filter", func(t *testing.T) {
+		_, root := parseMutationDocument(t, `<p></p>`)
+		observer := observe(t, root, spec.MutationObserverInit{AttributeFilter: []string{"hidden"}, Subtree: true})
+		root.SetAttribute("class", "a")
+		root.FirstElementChild().SetAttribute("hidden", "")
+		root.SetAttributeNS(spec.NamespaceXLink, "xlink:hidden", "")
+
+		records := observer.TakeRecords()
+		require.Len(t, records, 1)
+		assert.Equal(t, "hidden", records[0].AttributeName())
+		assert.Equal(t, "p", nodeName(records[0].Target()))
+	})
+	t.Run("namespace", func(t *testing.T) {
+		_, root := parseMutationDocument(t, ``)
+		observer := observe(t, root, spec.MutationObserverInit{Attributes: true})
+		root.SetAttributeNS(spec.NamespaceXLink, "xlink:href", "#a")
+		root.RemoveAttributeNS(spec.NamespaceXLink, "href")
+
+		records := observer.TakeRecords()
+		require.Len(t, records, 2)
+		assert.Equal(t, "href", records[0].AttributeName())
+		assert.Equal(t, spec.NamespaceXLink, records[0].AttributeNamespace())
+	})
+	t.Run("attribute nodes", func(t *testing.T) {
+		_, root := parseMutationDocument(t, ``)
+		root.SetAttribute("title", "before")
+		observer := observe(t, root, spec.MutationObserverInit{AttributeOldValue: true})
+		attr := root.GetAttributeNode("title")
+		attr.SetValue("after")
+		root.RemoveAttributeNode(attr)
+
+		records := observer.TakeRecords()
+		require.Len(t, records, 2)
+		assert.Equal(t, "before", records[0].OldValue())
+		assert.Equal(t, "after", records[1].OldValue())
+	})
+}
+
+func TestMutationObserver_characterData(t *testing.T) {
+	_, root := parseMutationDocument(t, `<p>hello</p>`)
+	observer := observe(t, root, spec.MutationObserverInit{CharacterDataOldValue: true, Subtree: true})
+	text := root.FirstElementChild().FirstChild().(spec.Text)
+	text.SetData("goodbye")
+	text.AppendData("!")
+	text.DeleteData(0, 4)
+
+	records := observer.TakeRecords()
+	require.Len(t, records, 3)
+	assert.Equal(t, spec.MutationCharacterData, records[0].Type())
+	assert.True(t, records[0].Target().IsSameNode(text))
+	assert.Equal(t, "hello", records[0].OldValue())
+	assert.Equal(t, "goodbye", records[1].OldValue())
+	assert.Equal(t, "goodbye!", records[2].OldValue())
+	assert.Equal(t, "bye!", text.Data())
+}
+
+func TestMutationObserver_Observe(t *testing.T) {
+	t.Run("requires a record type", func(t *testing.T) {
+		_, root := parseMutationDocument(t, ``)
+		observer := dom.NewMutationObserver(nil)
+		assert.PanicsWithValue(t, "dom: type error: one of ChildList, Attributes, or CharacterData must be true", func() {
+			observer.Observe(root, spec.MutationObserverInit{Subtree: true})
+		})
+	})
+	t.Run("replaces options", func(t *testing.T) {
+		_, root := parseMutationDocument(t, ``)
+		observer := observe(t, root, spec.MutationObserverInit{Attributes: true})
+		observer.Observe(root, spec.MutationObserverInit{ChildList: true})
+		root.SetAttribute("class", "a")
+		assert.Empty(t, observer.TakeRecords())
+	})
+	t.Run("each observer gets a record", func(t *testing.T) {
+		_, root := parseMutationDocument(t, ``)
+		first := observe(t, root, spec.MutationObserverInit{AttributeOldValue: true})
+		second := observe(t, root, spec.MutationObserverInit{Attributes: true})
+		root.SetAttribute("class", "a")
+		root.SetAttribute("class", "b")
+		assert.Equal(t, "a", first.TakeRecords()[1].OldValue())
+		assert.Equal(t, "", second.TakeRecords()[1].OldValue())
+	})
+}
+
+func TestMutationObserver_Disconnect(t *testing.T) {
+	_, root := parseMutationDocument(t, ``)
+	observer := observe(t, root, spec.MutationObserverInit{Attributes: true})
+	root.SetAttribute("class", "a")
+	observer.Disconnect()
+	assert.Empty(t, observer.TakeRecords())
+	root.SetAttribute("class", "b")
+	assert.Empty(t, observer.TakeRecords())
+}
+
+func TestFlushMutationObservers(t *testing.T) {
+	t.Run("callback", func(t *testing.T) {
+		_, root := parseMutationDocument(t, ``)
+		var calls [][]spec.MutationRecord
+		observer := dom.NewMutationObserver(func(records []spec.MutationRecord, observer spec.MutationObserver) {
+			calls = append(calls, records)
+		})
+		observer.Observe(root, spec.MutationObserverInit{Attributes: true})
+		defer observer.Disconnect()
+
+		dom.FlushMutationObservers()
+		assert.Empty(t, calls)
+
+		root.SetAttribute("class", "a")
+		root.SetAttribute("title", "b")
+		dom.FlushMutationObservers()
+		require.Len(t, calls, 1)
+		assert.Len(t, calls[0], 2)
+
+		dom.FlushMutationObservers()
+		assert.Len(t, calls, 1)
+	})
+	t.Run("mutations in callbacks", func(t *testing.T) {
+		_, root := parseMutationDocument(t, ``)
+		calls := 0
+		observer := dom.NewMutationObserver(func(records []spec.MutationRecord, observer spec.MutationObserver) {
+			calls++
+			if !root.HasAttribute("data-seen") {
+				root.SetAttribute("data-seen", "")
+			}
+		})
+		observer.Observe(root, spec.MutationObserverInit{Attributes: true})
+		defer observer.Disconnect()
+
+		root.SetAttribute("class", "a")
+		dom.FlushMutationObservers()
+		assert.Equal(t, 2, calls)
+	})
+	t.Run("observers of every document", func(t *testing.T) {
+		_, a := parseMutationDocument(t, ``)
+		_, b := parseMutationDocument(t, ``)
+		var notified []string
+		for name, root := range map[string]spec.Element{"a": a, "b": b} {
+			observer := dom.NewMutationObserver(func(records []spec.MutationRecord, observer spec.MutationObserver) {
+				notified = append(notified, name)
+			})
+			observer.Observe(root, spec.MutationObserverInit{Attributes: true})
+			defer observer.Disconnect()
+		}
+
+		a.SetAttribute("class", "a")
+		b.SetAttribute("class", "b")
+		dom.FlushMutationObservers()
+		assert.ElementsMatch(t, []string{"a", "b"}, notified)
+	})
+	t.Run("removed nodes are observed until flush", func(t *testing.T) {
+		_, root := parseMutationDocument(t, `<p>one</p>`)
+		observer := observe(t, root, spec.MutationObserverInit{Attributes: true, Subtree: true})
+		p := root.FirstElementChild()
+		p.Remove()
+		p.SetAttribute("class", "a")
+		assert.Len(t, observer.TakeRecords(), 1)
+
+		dom.FlushMutationObservers()
+		p.SetAttribute("class", "b")
+		assert.Empty(t, observer.TakeRecords())
+	})
+}
+
+func TestMutationObserver_Flush(t *testing.T) {
+	t.Parallel()
+	_, root := parseMutationDocument(t, ``)
+	_, other := parseMutationDocument(t, ``)
+	calls := 0
+	observer := dom.NewMutationObserver(func(records []spec.MutationRecord, observer spec.MutationObserver) {
+		calls++
+		if !root.HasAttribute("data-seen") {
+			root.SetAttribute("data-seen", "")
+		}
+	})
+	observer.Observe(root, spec.MutationObserverInit{Attributes: true})
+	defer observer.Disconnect()
+	otherObserver := observe(t, other, spec.MutationObserverInit{Attributes: true})
+
+	root.SetAttribute("class", "a")
+	other.SetAttribute("class", "a")
+	observer.(spec.MutationObserverFlusher).Flush()
+	assert.Equal(t, 2, calls, "the callback is called again for its own mutations")
+	assert.Len(t, otherObserver.TakeRecords(), 1, "other observers keep their records")
+
+	observer.(spec.MutationObserverFlusher).Flush()
+	assert.Equal(t, 2, calls)
+}
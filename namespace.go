@@ -0,0 +1,321 @@
+package dom
+
+import (
+	"runtime"
+	"slices"
+	"strings"
+	"sync"
+	"weak"
+
+	"golang.org/x/net/html"
+	"golang.org/x/net/html/atom"
+
+	"github.com/typelate/dom/spec"
+)
+
+// elementNamespaces maps the values x/net/html stores in html.Node.Namespace to namespace URIs.
+// Elements created with other namespaces, or with a prefix in the HTML namespace,
+// store the namespace URI in html.Node.Namespace.
+var elementNamespaces = map[string]string{
+	"":     spec.NamespaceHTML,
+	"svg":  spec.NamespaceSVG,
+	"math": spec.NamespaceMathML,
+}
+
+func elementNamespaceURI(node *html.Node) string {
+	if ns, ok := elementNamespaces[node.Namespace]; ok {
+		return ns
+	}
+	return node.Namespace
+}
+
+// isInHTMLNamespace reports whether the element is in the HTML namespace. HTML elements created with
+// a prefix store the namespace URI in html.Node.Namespace so that the prefix in html.Node.Data is kept.
+func isInHTMLNamespace(node *html.Node) bool { return elementNamespaceURI(node) == spec.NamespaceHTML }
+
+// elementPrefix returns the prefix of the qualified name stored in html.Node.Data.
+// Elements parsed as HTML never have a prefix.
+func elementPrefix(node *html.Node) string {
+	if node.Namespace == "" {
+		return ""
+	}
+	prefix, _, found := strings.Cut(node.Data, ":")
+	if !found {
+		return ""
+	}
+	return prefix
+}
+
+func elementLocalName(node *html.Node) string {
+	if node.Namespace == "" {
+		return node.Data
+	}
+	_, localName, found := strings.Cut(node.Data, ":")
+	if !found {
+		return node.Data
+	}
+	return localName
+}
+
+// createElementNS is based on https://dom.spec.whatwg.org/#internal-createelementns-steps
+func createElementNS(namespace, qualifiedName string) *html.Node {
+	prefix, localName := validateAndExtract(namespace, qualifiedName)
+	if namespace == "" {
+		panic("dom: CreateElementNS does not support the null namespace")
+	}
+	node := &html.Node{
+		Type:      html.ElementNode,
+		Namespace: namespace,
+		Data:      qualifiedName,
+	}
+	for value, ns := range elementNamespaces {
+		if ns == namespace && (prefix == "" || value != "") {
+			node.Namespace = value
+		}
+	}
+	if prefix == "" && node.Namespace != namespace {
+		node.DataAtom = atom.Lookup([]byte(strings.ToLower(localName)))
+	}
+	return node
+}
+
+// attributeNamespacePrefixes maps the prefixes x/net/html stores in html.Attribute.Namespace to namespace URIs.
+// See https://html.spec.whatwg.org/multipage/parsing.html#adjust-foreign-attributes
+var attributeNamespacePrefixes = map[string]string{
+	"xlink": spec.NamespaceXLink,
+	"xml":   spec.NamespaceXML,
+	"xmlns": spec.NamespaceXMLNS,
+}
+
+// attributeName identifies an attribute on an element the same way Attr does.
+type attributeName struct {
+	prefix, localName string
+}
+
+// attributeNamespaces holds the namespace URI of attributes added by SetAttributeNS and SetAttributeNode
+// because html.Attribute only records a prefix. Elements are weak keys so that the table does not keep them alive.
+// An entry is removed with its attribute by removeAttributeAt.
+var attributeNamespaces = struct {
+	sync.Mutex
+	elements map[weak.Pointer[html.Node]]map[attributeName]string
+}{elements: make(map[weak.Pointer[html.Node]]map[attributeName]string)}
+
+func storedAttributeNamespace(element *html.Node, att html.Attribute) (string, bool) {
+	attributeNamespaces.Lock()
+	defer attributeNamespaces.Unlock()
+	namespace, ok := attributeNamespaces.elements[weak.Make(element)][attributeName{prefix: att.Namespace, localName: att.Key}]
+	return namespace, ok
+}
+
+// storeAttributeNamespace records the namespace URI of att. The null namespace removes the entry.
+func storeAttributeNamespace(element *html.Node, att html.Attribute, namespace string) {
+	attributeNamespaces.Lock()
+	defer attributeNamespaces.Unlock()
+	key := weak.Make(element)
+	name := attributeName{prefix: att.Namespace, localName: att.Key}
+	names, ok := attributeNamespaces.elements[key]
+	if namespace == "" {
+		if ok {
+			delete(names, name)
+			if len(names) == 0 {
+				delete(attributeNamespaces.elements, key)
+			}
+		}
+		return
+	}
+	if !ok {
+		names = make(map[attributeName]string)
+		attributeNamespaces.elements[key] = names
+		runtime.AddCleanup(element, func(key weak.Pointer[html.Node]) {
+			attributeNamespaces.Lock()
+			defer attributeNamespaces.Unlock()
+			delete(attributeNamespaces.elements, key)
+		}, key)
+	}
+	names[name] = namespace
+}
+
+// copyAttributeNamespaces copies the stored namespace URIs of the attributes of element to clone.
+func copyAttributeNamespaces(element, clone *html.Node) {
+	for _, att := range element.Attr {
+		if namespace, ok := storedAttributeNamespace(element, att); ok {
+			storeAttributeNamespace(clone, att, namespace)
+		}
+	}
+}
+
+// removeAttributeAt removes the attribute at index i and queues a MutationRecord.
+func removeAttributeAt(element *html.Node, i int) {
+	mutated(element)
+	att := element.Attr[i]
+	queueAttributeRecord(element, att, att.Val)
+	storeAttributeNamespace(element, att, "")
+	element.Attr = slices.Delete(element.Attr, i, i+1)
+}
+
+// attributeNamespaceURI resolves the namespace of an attribute.
+// Namespaces recorded by storeAttributeNamespace are used first. Otherwise, prefixes other than
+// xlink, xml, and xmlns are resolved using xmlns:prefix declarations on the element and its ancestors.
+func attributeNamespaceURI(element *html.Node, att html.Attribute) string {
+	if element != nil {
+		if namespace, ok := storedAttributeNamespace(element, att); ok {
+			return namespace
+		}
+	}
+	if att.Namespace == "" {
+		if att.Key == "xmlns" && element != nil && element.Namespace != "" {
+			return spec.NamespaceXMLNS
+		}
+		return ""
+	}
+	if ns, ok := attributeNamespacePrefixes[att.Namespace]; ok {
+		return ns
+	}
+	for n := element; n != nil; n = treeParent(n) {
+		if n.Type != html.ElementNode {
+			continue
+		}
+		for _, a := range n.Attr {
+			if a.Namespace == "xmlns" && a.Key == att.Namespace {
+				return a.Val
+			}
+		}
+	}
+	return ""
+}
+
+// attributeIndexNS returns the index of the attribute with namespace and localName or -1.
+func attributeIndexNS(node *html.Node, namespace, localName string) int {
+	for i, att := range node.Attr {
+		if att.Key == localName && attributeNamespaceURI(node, att) == namespace {
+			return i
+		}
+	}
+	return -1
+}
+
+// locateNamespace is based on https://dom.spec.whatwg.org/#locate-a-namespace
+// An empty prefix or result represents null.
+func locateNamespace(node *html.Node, prefix string) string {
+	if node == nil {
+		return ""
+	}
+	switch node.Type {
+	case html.ElementNode:
+		switch prefix {
+		case "xml":
+			return spec.NamespaceXML
+		case "xmlns":
+			return spec.NamespaceXMLNS
+		}
+		if elementPrefix(node) == prefix {
+			return elementNamespaceURI(node)
+		}
+		for _, att := range node.Attr {
+			if attributeNamespaceURI(node, att) != spec.NamespaceXMLNS {
+				continue
+			}
+			if (prefix != "" && att.Namespace == "xmlns" && att.Key == prefix) ||
+				(prefix == "" && att.Namespace == "" && att.Key == "xmlns") {
+				return att.Val
+			}
+		}
+		return locateNamespace(parentElementNode(node), prefix)
+	case html.DocumentNode:
+		return locateNamespace(documentElement(node), prefix)
+	case html.DoctypeNode:
+		return ""
+	default:
+		return locateNamespace(parentElementNode(node), prefix)
+	}
+}
+
+// locateNamespacePrefix is based on https://dom.spec.whatwg.org/#locate-a-namespace-prefix
+func locateNamespacePrefix(element *html.Node, namespace string) string {
+	for ; element != nil; element = parentElementNode(element) {
+		if prefix := elementPrefix(element); prefix != "" && elementNamespaceURI(element) == namespace {
+			return prefix
+		}
+		for _, att := range element.Attr {
+			if att.Namespace == "xmlns" && att.Val == namespace {
+				return att.Key
+			}
+		}
+	}
+	return ""
+}
+
+// lookupPrefix is based on https://dom.spec.whatwg.org/#dom-node-lookupprefix
+func lookupPrefix(node *html.Node, namespace string) string {
+	if namespace == "" {
+		return ""
+	}
+	switch node.Type {
+	case html.ElementNode:
+		return locateNamespacePrefix(node, namespace)
+	case html.DocumentNode:
+		return locateNamespacePrefix(documentElement(node), namespace)
+	case html.DoctypeNode:
+		return ""
+	default:
+		return locateNamespacePrefix(parentElementNode(node), namespace)
+	}
+}
+
+// isDefaultNamespace is based on https://dom.spec.whatwg.org/#dom-node-isdefaultnamespace
+func isDefaultNamespace(node *html.Node, namespace string) bool {
+	return locateNamespace(node, "") == namespace
+}
+
+func parentElementNode(node *html.Node) *html.Node {
+	if p := treeParent(node); p != nil && p.Type == html.ElementNode {
+		return p
+	}
+	return nil
+}
+
+// validateAndExtract is based on https://dom.spec.whatwg.org/#validate-and-extract
+// Instead of throwing a NamespaceError it panics.
+func validateAndExtract(namespace, qualifiedName string) (prefix, localName string) {
+	localName = qualifiedName
+	if before, after, found := strings.Cut(qualifiedName, ":"); found {
+		prefix, localName = before, after
+		if prefix == "" || strings.Contains(localName, ":") {
+			panic("dom: invalid qualified name " + qualifiedName)
+		}
+	}
+	if localName == "" {
+		panic("dom: invalid qualified name " + qualifiedName)
+	}
+	switch {
+	case prefix != "" && namespace == "":
+		panic("dom: namespace error: prefix " + prefix + " requires a namespace")
+	case prefix == "xml" && namespace != spec.NamespaceXML:
+		panic("dom: namespace error: prefix xml requires the XML namespace")
+	case (qualifiedName == "xmlns" || prefix == "xmlns") != (namespace == spec.NamespaceXMLNS):
+		panic("dom: namespace error: xmlns must be used with the XMLNS namespace")
+	}
+	return prefix, localName
+}
+
+// setAttributeNS is based on https://dom.spec.whatwg.org/#concept-element-attributes-set-value
+// An attribute with the same prefix and local name in another namespace is replaced, so
+// the element does not serialize with two attributes that have the same qualified name.
+func setAttributeNS(node *html.Node, namespace, qualifiedName, value string) {
+	prefix, localName := validateAndExtract(namespace, qualifiedName)
+	mutated(node)
+	if i := attributeIndexNS(node, namespace, localName); i >= 0 {
+		queueAttributeRecord(node, node.Attr[i], node.Attr[i].Val)
+		node.Attr[i].Val = value
+		return
+	}
+	att := html.Attribute{Namespace: prefix, Key: localName, Val: value}
+	if i := slices.IndexFunc(node.Attr, func(existing html.Attribute) bool {
+		return existing.Namespace == prefix && existing.Key == localName
+	}); i >= 0 {
+		removeAttributeAt(node, i)
+	}
+	queueAttributeRecord(node, att, "")
+	storeAttributeNamespace(node, att, namespace)
+	node.Attr = append(node.Attr, att)
+}
@@ -0,0 +1,333 @@
+package dom
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/typelate/dom/spec"
+)
+
+func TestElement_GetAttributeNS(t *testing.T) {
+	// language=html
+	document, _ := parseDocument(t, `<!DOCTYPE html><html><head></head><body>
+<svg xmlns="http://www.w3.org/2000/svg" xmlns:xlink="http://www.w3.org/1999/xlink" viewBox="0 0 10 10">
+<use id="icon" xlink:href="#shape" href="#plain"/>
+<text id="label" xml:lang="en-US">Hi</text>
+</svg>
+</body></html>`, "")
+	icon := document.QuerySelector("#icon")
+	label := document.QuerySelector("#label")
+	svg := document.QuerySelector("svg")
+
+	assert.Equal(t, "#shape", icon.GetAttributeNS(spec.NamespaceXLink, "href"))
+	assert.Equal(t, "#plain", icon.GetAttributeNS("", "href"))
+	assert.Equal(t, "en-US", label.GetAttributeNS(spec.NamespaceXML, "lang"))
+	assert.Equal(t, spec.NamespaceXLink, svg.GetAttributeNS(spec.NamespaceXMLNS, "xlink"))
+	assert.Equal(t, spec.NamespaceSVG, svg.GetAttributeNS(spec.NamespaceXMLNS, "xmlns"))
+	assert.Zero(t, icon.GetAttributeNS(spec.NamespaceXML, "href"))
+
+	assert.True(t, icon.HasAttributeNS(spec.NamespaceXLink, "href"))
+	assert.False(t, icon.HasAttributeNS(spec.NamespaceXLink, "title"))
+
+	t.Run("qualified names", func(t *testing.T) {
+		assert.Equal(t, "#shape", icon.GetAttribute("xlink:href"))
+		assert.Equal(t, "#plain", icon.GetAttribute("href"))
+		assert.Equal(t, "0 0 10 10", svg.GetAttribute("viewBox"))
+		assert.Zero(t, svg.GetAttribute("viewbox"))
+	})
+	t.Run("attribute nodes", func(t *testing.T) {
+		attr := icon.Attributes().GetNamedItemNS(spec.NamespaceXLink, "href")
+		require.NotNil(t, attr)
+		assert.Equal(t, spec.NamespaceXLink, attr.NamespaceURI())
+		assert.Equal(t, "xlink", attr.Prefix())
+		assert.Equal(t, "href", attr.LocalName())
+		assert.Equal(t, "xlink:href", attr.Name())
+	})
+}
+
+func TestElement_SetAttributeNS(t *testing.T) {
+	t.Run("update existing", func(t *testing.T) {
+		// language=html
+		_, icon := parseDocument(t, `<!DOCTYPE html><html><head></head><body><svg><use id="icon" xlink:href="#a"/></svg></body></html>`, "#icon")
+
+		icon.SetAttributeNS(spec.NamespaceXLink, "xlink:href", "#b")
+
+		assert.Equal(t, "#b", icon.GetAttributeNS(spec.NamespaceXLink, "href"))
+		assert.Equal(t, 2, icon.Attributes().Length())
+	})
+	t.Run("add without a prefix", func(t *testing.T) {
+		// language=html
+		_, icon := parseDocument(t, `<!DOCTYPE html><html><head></head><body><svg><use id="icon"/></svg></body></html>`, "#icon")
+
+		icon.SetAttributeNS(spec.NamespaceXLink, "href", "#b")
+
+		assert.Equal(t, "#b", icon.GetAttributeNS(spec.NamespaceXLink, "href"))
+		assert.False(t, icon.HasAttributeNS("", "href"))
+		assert.Equal(t, []string{"id", "href"}, icon.GetAttributeNames())
+		attr := icon.Attributes().GetNamedItemNS(spec.NamespaceXLink, "href")
+		require.NotNil(t, attr)
+		assert.Equal(t, "href", attr.Name())
+		assert.Empty(t, attr.Prefix())
+		assert.Equal(t, spec.NamespaceXLink, attr.NamespaceURI())
+	})
+	t.Run("add without a prefix in another namespace", func(t *testing.T) {
+		// language=html
+		_, el := parseDocument(t, `<!DOCTYPE html><html><head></head><body><div id="a"></div></body></html>`, "#a")
+
+		el.SetAttributeNS("http://example.com/ns", "foo", "v")
+
+		assert.Equal(t, "v", el.GetAttributeNS("http://example.com/ns", "foo"))
+		assert.Equal(t, "v", el.GetAttribute("foo"))
+		assert.Equal(t, []string{"id", "foo"}, el.GetAttributeNames())
+		el.RemoveAttributeNS("http://example.com/ns", "foo")
+		assert.False(t, el.HasAttribute("foo"))
+	})
+	t.Run("add with declared prefix", func(t *testing.T) {
+		// language=html
+		_, el := parseDocument(t, `<!DOCTYPE html><html><head></head><body><svg><g id="group"></g></svg></body></html>`, "#group")
+
+		el.SetAttributeNS("https://example.com/ns", "ex:role", "chart")
+		el.SetAttributeNS(spec.NamespaceXMLNS, "xmlns:ex", "https://example.com/ns")
+
+		assert.Equal(t, "chart", el.GetAttributeNS("https://example.com/ns", "role"))
+		assert.Equal(t, "chart", el.GetAttribute("ex:role"))
+	})
+	t.Run("add without a declared prefix", func(t *testing.T) {
+		// language=html
+		_, el := parseDocument(t, `<!DOCTYPE html><html><head></head><body><div id="a"></div></body></html>`, "#a")
+
+		el.SetAttributeNS("http://example.com/ns", "ex:foo", "1")
+
+		assert.Equal(t, "1", el.GetAttributeNS("http://example.com/ns", "foo"))
+		assert.True(t, el.HasAttributeNS("http://example.com/ns", "foo"))
+		assert.False(t, el.HasAttributeNS("", "foo"))
+		assert.Equal(t, "1", el.GetAttribute("ex:foo"))
+
+		attr := el.Attributes().GetNamedItemNS("http://example.com/ns", "foo")
+		require.NotNil(t, attr)
+		assert.Equal(t, "http://example.com/ns", attr.NamespaceURI())
+		assert.Equal(t, "http://example.com/ns", attr.CloneNode(false).(spec.Attr).NamespaceURI())
+
+		clone := el.CloneNode(false).(spec.Element)
+		assert.Equal(t, "1", clone.GetAttributeNS("http://example.com/ns", "foo"))
+
+		el.RemoveAttributeNS("http://example.com/ns", "foo")
+		assert.False(t, el.HasAttributeNS("http://example.com/ns", "foo"))
+		assert.Equal(t, "http://example.com/ns", attr.NamespaceURI(), "a removed attribute keeps its namespace")
+		el.SetAttribute("ex:foo", "2")
+		assert.False(t, el.HasAttributeNS("http://example.com/ns", "foo"), "the namespace is removed with the attribute")
+	})
+	t.Run("set twice", func(t *testing.T) {
+		// language=html
+		_, el := parseDocument(t, `<!DOCTYPE html><html><head></head><body><div id="a"></div></body></html>`, "#a")
+
+		el.SetAttributeNS("http://example.com/ns", "ex:foo", "1")
+		el.SetAttributeNS("http://example.com/ns", "ex:foo", "2")
+
+		assert.Equal(t, []string{"id", "ex:foo"}, el.GetAttributeNames())
+		assert.Equal(t, "2", el.GetAttributeNS("http://example.com/ns", "foo"))
+		assert.Equal(t, `<div id="a" ex:foo="2"></div>`, el.OuterHTML())
+	})
+	t.Run("set xmlns twice on an HTML element", func(t *testing.T) {
+		// language=html
+		_, el := parseDocument(t, `<!DOCTYPE html><html><head></head><body><div id="a"></div></body></html>`, "#a")
+
+		el.SetAttributeNS(spec.NamespaceXMLNS, "xmlns", "http://example.com/a")
+		el.SetAttributeNS(spec.NamespaceXMLNS, "xmlns", "http://example.com/b")
+
+		assert.Equal(t, []string{"id", "xmlns"}, el.GetAttributeNames())
+		assert.Equal(t, "http://example.com/b", el.GetAttributeNS(spec.NamespaceXMLNS, "xmlns"))
+	})
+	t.Run("null namespace", func(t *testing.T) {
+		// language=html
+		_, el := parseDocument(t, `<!DOCTYPE html><html><head></head><body><div id="a"></div></body></html>`, "#a")
+
+		el.SetAttributeNS("", "title", "t")
+
+		assert.Equal(t, "t", el.GetAttribute("title"))
+	})
+	t.Run("namespace errors", func(t *testing.T) {
+		// language=html
+		_, el := parseDocument(t, `<!DOCTYPE html><html><head></head><body><div id="a"></div></body></html>`, "#a")
+
+		assert.Panics(t, func() { el.SetAttributeNS("", "xlink:href", "") })
+		assert.Panics(t, func() { el.SetAttributeNS(spec.NamespaceXLink, "xml:lang", "") })
+		assert.Panics(t, func() { el.SetAttributeNS(spec.NamespaceXLink, "xmlns:xlink", "") })
+		assert.Panics(t, func() { el.SetAttributeNS(spec.NamespaceXMLNS, "foo", "") })
+		assert.Panics(t, func() { el.SetAttributeNS(spec.NamespaceXLink, ":href", "") })
+		assert.Panics(t, func() { el.SetAttributeNS(spec.NamespaceXLink, "a:b:c", "") })
+	})
+}
+
+func TestElement_RemoveAttributeNS(t *testing.T) {
+	// language=html
+	_, icon := parseDocument(t, `<!DOCTYPE html><html><head></head><body><svg><use id="icon" xlink:href="#a" href="#b"/></svg></body></html>`, "#icon")
+
+	icon.RemoveAttributeNS(spec.NamespaceXLink, "href")
+
+	assert.False(t, icon.HasAttributeNS(spec.NamespaceXLink, "href"))
+	assert.True(t, icon.HasAttributeNS("", "href"))
+
+	removed := icon.Attributes().RemoveNamedItemNS("", "href")
+	require.NotNil(t, removed)
+	assert.Equal(t, "#b", removed.Value())
+	assert.False(t, icon.HasAttribute("href"))
+}
+
+func TestElement_TagName(t *testing.T) {
+	// language=html
+	document, _ := parseDocument(t, `<!DOCTYPE html><html><head></head><body>
+<svg><linearGradient id="gradient"></linearGradient><foreignObject><p id="paragraph">x</p></foreignObject></svg>
+<math><mi id="identifier">x</mi></math>
+</body></html>`, "")
+
+	for _, tt := range []struct {
+		Selector     string
+		TagName      string
+		LocalName    string
+		NamespaceURI string
+	}{
+		{Selector: "body", TagName: "BODY", LocalName: "body", NamespaceURI: spec.NamespaceHTML},
+		{Selector: "svg", TagName: "svg", LocalName: "svg", NamespaceURI: spec.NamespaceSVG},
+		{Selector: "#gradient", TagName: "linearGradient", LocalName: "linearGradient", NamespaceURI: spec.NamespaceSVG},
+		{Selector: "#paragraph", TagName: "P", LocalName: "p", NamespaceURI: spec.NamespaceHTML},
+		{Selector: "#identifier", TagName: "mi", LocalName: "mi", NamespaceURI: spec.NamespaceMathML},
+	} {
+		t.Run(tt.Selector, func(t *testing.T) {
+			el := document.QuerySelector(tt.Selector)
+			require.NotNil(t, el)
+			assert.Equal(t, tt.TagName, el.TagName())
+			assert.Equal(t, tt.LocalName, el.LocalName())
+			assert.Equal(t, tt.NamespaceURI, el.NamespaceURI())
+			assert.Zero(t, el.Prefix())
+		})
+	}
+	t.Run("foreignObject", func(t *testing.T) {
+		el := document.QuerySelector("#paragraph").ParentElement()
+		require.NotNil(t, el)
+		assert.Equal(t, "foreignObject", el.TagName())
+	})
+}
+
+func TestDocument_CreateElementNS(t *testing.T) {
+	var document *Document
+
+	t.Run("svg", func(t *testing.T) {
+		svg := document.CreateElementNS(spec.NamespaceSVG, "svg")
+		gradient := document.CreateElementNS(spec.NamespaceSVG, "linearGradient")
+		svg.Append(gradient)
+
+		assert.Equal(t, "linearGradient", gradient.TagName())
+		assert.Equal(t, spec.NamespaceSVG, gradient.NamespaceURI())
+		assert.Equal(t, "svg", gradient.(*Element).node.Namespace)
+		assert.Equal(t, `<svg><linearGradient></linearGradient></svg>`, svg.OuterHTML())
+		assert.True(t, svg.Matches("svg"))
+	})
+	t.Run("html", func(t *testing.T) {
+		div := document.CreateElementNS(spec.NamespaceHTML, "div")
+
+		assert.Equal(t, "DIV", div.TagName())
+		assert.Equal(t, spec.NamespaceHTML, div.NamespaceURI())
+		assert.Equal(t, document.CreateElement("div").(*Element).node, div.(*Element).node)
+	})
+	t.Run("prefixed html", func(t *testing.T) {
+		div := document.CreateElementNS(spec.NamespaceHTML, "h:div")
+
+		assert.Equal(t, "H:DIV", div.TagName())
+		assert.Equal(t, "h", div.Prefix())
+		assert.Equal(t, "div", div.LocalName())
+		assert.Equal(t, spec.NamespaceHTML, div.NamespaceURI())
+		assert.Equal(t, spec.NamespaceHTML, div.LookupNamespaceURI("h"))
+
+		div.SetAttribute("Title", "t")
+		assert.Equal(t, "t", div.GetAttribute("TITLE"))
+		assert.Equal(t, `<h:div title="t"></h:div>`, div.OuterHTML())
+	})
+	t.Run("prefixed", func(t *testing.T) {
+		el := document.CreateElementNS("https://example.com/ns", "ex:chart")
+
+		assert.Equal(t, "ex:chart", el.TagName())
+		assert.Equal(t, "ex", el.Prefix())
+		assert.Equal(t, "chart", el.LocalName())
+		assert.Equal(t, "https://example.com/ns", el.NamespaceURI())
+	})
+	t.Run("errors", func(t *testing.T) {
+		assert.Panics(t, func() { document.CreateElementNS("", "div") })
+		assert.Panics(t, func() { document.CreateElementNS("", "ex:div") })
+		assert.Panics(t, func() { document.CreateElementNS(spec.NamespaceSVG, "xml:svg") })
+	})
+}
+
+func TestNode_LookupNamespaceURI(t *testing.T) {
+	// language=html
+	document, _ := parseDocument(t, `<!DOCTYPE html><html><head></head><body><p id="p">text<!--comment--></p>
+<svg xmlns:xlink="http://www.w3.org/1999/xlink"><g id="g">shape</g></svg>
+</body></html>`, "")
+	p := document.QuerySelector("#p")
+	g := document.QuerySelector("#g")
+	text := p.FirstChild().(*Text)
+	comment := p.LastChild().(*Comment)
+
+	assert.Equal(t, spec.NamespaceHTML, p.LookupNamespaceURI(""))
+	assert.Equal(t, spec.NamespaceHTML, text.LookupNamespaceURI(""))
+	assert.Equal(t, spec.NamespaceHTML, comment.LookupNamespaceURI(""))
+	assert.Equal(t, spec.NamespaceSVG, g.LookupNamespaceURI(""))
+	assert.Equal(t, spec.NamespaceSVG, g.FirstChild().(*Text).LookupNamespaceURI(""))
+	assert.Equal(t, spec.NamespaceXLink, g.LookupNamespaceURI("xlink"))
+	assert.Equal(t, "", p.LookupNamespaceURI("xlink"))
+	assert.Equal(t, spec.NamespaceXML, p.LookupNamespaceURI("xml"))
+	assert.Equal(t, spec.NamespaceXMLNS, p.LookupNamespaceURI("xmlns"))
+	assert.Equal(t, "", document.Doctype().LookupNamespaceURI(""))
+
+	detached := document.CreateTextNode("x").(*Text)
+	assert.Equal(t, "", detached.LookupNamespaceURI(""))
+
+	t.Run("declared", func(t *testing.T) {
+		el := document.CreateElementNS("urn:example", "ex:item")
+		el.SetAttributeNS(spec.NamespaceXMLNS, "xmlns:other", "urn:other")
+		el.SetAttributeNS(spec.NamespaceXMLNS, "xmlns", "urn:default")
+		child := document.CreateElementNS("urn:child", "child")
+		el.Append(child)
+
+		assert.Equal(t, "urn:example", el.LookupNamespaceURI("ex"))
+		assert.Equal(t, "urn:other", child.LookupNamespaceURI("other"))
+		assert.Equal(t, "urn:default", el.LookupNamespaceURI(""))
+		assert.Equal(t, "urn:child", child.LookupNamespaceURI(""))
+	})
+}
+
+func TestNode_LookupPrefix(t *testing.T) {
+	// language=html
+	document, _ := parseDocument(t, `<!DOCTYPE html><html><head></head><body><p id="p">text</p>
+<svg xmlns:xlink="http://www.w3.org/1999/xlink"><g id="g">shape</g></svg>
+</body></html>`, "")
+	p := document.QuerySelector("#p")
+	g := document.QuerySelector("#g")
+
+	assert.Equal(t, "xlink", g.LookupPrefix(spec.NamespaceXLink))
+	assert.Equal(t, "xlink", g.FirstChild().(*Text).LookupPrefix(spec.NamespaceXLink))
+	assert.Equal(t, "", g.LookupPrefix(spec.NamespaceSVG))
+	assert.Equal(t, "", p.LookupPrefix(spec.NamespaceXLink))
+	assert.Equal(t, "", p.LookupPrefix(""))
+
+	el := document.CreateElementNS("urn:example", "ex:item")
+	child := document.CreateElementNS("urn:child", "child")
+	el.Append(child)
+	assert.Equal(t, "ex", el.LookupPrefix("urn:example"))
+	assert.Equal(t, "ex", child.LookupPrefix("urn:example"))
+}
+
+func TestNode_IsDefaultNamespace(t *testing.T) {
+	// language=html
+	document, _ := parseDocument(t, `<!DOCTYPE html><html><head></head><body><p id="p"></p><svg><g id="g"></g></svg></body></html>`, "")
+	p := document.QuerySelector("#p")
+	g := document.QuerySelector("#g")
+
+	assert.True(t, p.IsDefaultNamespace(spec.NamespaceHTML))
+	assert.False(t, p.IsDefaultNamespace(spec.NamespaceSVG))
+	assert.True(t, g.IsDefaultNamespace(spec.NamespaceSVG))
+	assert.True(t, document.Doctype().IsDefaultNamespace(""))
+	require.False(t, document.Doctype().IsDefaultNamespace(spec.NamespaceHTML))
+}
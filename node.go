@@ -52,6 +52,10 @@ func NewNode(node *html.Node) spec.Node {
 		return &Text{node: node}
 	case html.DocumentNode:
 		return &Document{node: node}
+	case html.CommentNode:
+		return &Comment{node: node}
+	case html.DoctypeNode:
+		return &DocumentType{node: node}
 	default:
 		panic("not supported")
 	}
@@ -66,13 +70,17 @@ func htmlNodeToDomChildNode(node *html.Node) spec.ChildNode {
 		return &Element{node: node}
 	case html.TextNode:
 		return &Text{node: node}
+	case html.CommentNode:
+		return &Comment{node: node}
+	case html.DoctypeNode:
+		return &DocumentType{node: node}
 	default:
 		panic("not supported")
 	}
 }
 
 func htmlNodeToDomElement(node *html.Node) spec.Element {
-	if node == nil {
+	if node == nil || node.Type != html.ElementNode {
 		return nil
 	}
 	return &Element{node: node}
@@ -86,15 +94,26 @@ func domNodeToHTMLNode(node spec.Node) *html.Node {
 		return ot.node
 	case *Document:
 		return ot.node
+	case *Comment:
+		return ot.node
+	case *DocumentType:
+		return ot.node
+	case *Attr:
+		return nil
 	default:
 		panic("not implemented")
 	}
 }
 
+// walkNodes calls fn for start and its descendants in tree order until fn returns true.
+// The contents of a template element are a separate tree, so they are not walked.
 func walkNodes(start *html.Node, fn func(node *html.Node) (done bool)) bool {
 	if fn(start) {
 		return true
 	}
+	if isTemplateElement(start) {
+		return false
+	}
 
 	c := start.FirstChild
 	for c != nil {
@@ -133,12 +152,12 @@ func (node *firstChildIterator) Item(index int) spec.Node {
 }
 
 func isConnected(node *html.Node) bool {
-	p := node.Parent
+	p := treeParent(node)
 	for p != nil {
 		if p.Type == html.DocumentNode {
 			return true
 		}
-		p = p.Parent
+		p = treeParent(p)
 	}
 	return false
 }
@@ -161,14 +180,14 @@ func ownerDocumentNode(node *html.Node) *html.Node {
 	}
 	return nil
 }
-func parentNode(node *html.Node) spec.Node       { return NewNode(node.Parent) }
-func parentElement(node *html.Node) spec.Element { return htmlNodeToDomElement(node.Parent) }
-func hasChildNodes(node *html.Node) bool         { return node.FirstChild != nil }
+func parentNode(node *html.Node) spec.Node       { return NewNode(treeParent(node)) }
+func parentElement(node *html.Node) spec.Element { return htmlNodeToDomElement(treeParent(node)) }
+func hasChildNodes(node *html.Node) bool         { return treeFirstChild(node) != nil }
 func childNodes(node *html.Node) spec.NodeList[spec.Node] {
-	return (*firstChildIterator)(node.FirstChild)
+	return (*firstChildIterator)(treeFirstChild(node))
 }
-func firstChild(node *html.Node) spec.ChildNode      { return htmlNodeToDomChildNode(node.FirstChild) }
-func lastChild(node *html.Node) spec.ChildNode       { return htmlNodeToDomChildNode(node.LastChild) }
+func firstChild(node *html.Node) spec.ChildNode      { return htmlNodeToDomChildNode(treeFirstChild(node)) }
+func lastChild(node *html.Node) spec.ChildNode       { return htmlNodeToDomChildNode(treeLastChild(node)) }
 func previousSibling(node *html.Node) spec.ChildNode { return htmlNodeToDomChildNode(node.PrevSibling) }
 func nextSibling(node *html.Node) spec.ChildNode     { return htmlNodeToDomChildNode(node.NextSibling) }
 
@@ -185,6 +204,9 @@ func recursiveTextContent(sw io.StringWriter, n *html.Node) {
 			panic(err)
 		}
 	}
+	if isTemplateElement(n) {
+		return
+	}
 	c := n.FirstChild
 	for c != nil {
 		recursiveTextContent(sw, c)
@@ -211,6 +233,7 @@ func cloneNode(node *html.Node, deep bool) *html.Node {
 			result.Attr[i].Val = at.Val
 			result.Attr[i].Namespace = at.Namespace
 		}
+		copyAttributeNamespaces(node, result)
 	}
 	return result
 }
@@ -241,19 +264,18 @@ func contains(node *html.Node, other spec.Node) bool {
 func insertBefore(parent *html.Node, node, child spec.ChildNode) spec.ChildNode {
 	n := domNodeToHTMLNode(node)
 	c := domNodeToHTMLNode(child)
-	if n.Parent != nil {
-		n.Parent.RemoveChild(n)
+	if c == n {
+		c = n.NextSibling
 	}
-	parent.InsertBefore(n, c)
+	removeHTMLNode(n)
+	insertHTMLNode(parent, n, c)
 	return htmlNodeToDomChildNode(n)
 }
 
 func appendChild(parent *html.Node, node spec.ChildNode) spec.ChildNode {
 	n := domNodeToHTMLNode(node)
-	if n.Parent != nil {
-		n.Parent.RemoveChild(n)
-	}
-	parent.AppendChild(n)
+	removeHTMLNode(n)
+	insertHTMLNode(parent, n, nil)
 	return htmlNodeToDomChildNode(n)
 }
 
@@ -263,41 +285,29 @@ func replaceChild(parent *html.Node, node, child spec.ChildNode) spec.ChildNode
 	if c.Parent != parent {
 		panic("browser: ReplaceChild called for an attached child node")
 	}
-	if c.PrevSibling != nil {
-		c.PrevSibling.NextSibling = n
+	if n == c {
+		return htmlNodeToDomChildNode(c)
 	}
-	if c.NextSibling != nil {
-		c.NextSibling.PrevSibling = n
-	}
-	if parent.FirstChild == c {
-		parent.FirstChild = n
-	}
-	if parent.LastChild == c {
-		parent.LastChild = n
-	}
-	n.PrevSibling = c.PrevSibling
-	n.NextSibling = c.NextSibling
-	n.Parent = c.Parent
-
-	c.PrevSibling = nil
-	c.NextSibling = nil
-	c.Parent = nil
-
+	removeHTMLNode(n)
+	replaceHTMLNode(c, []*html.Node{n})
 	return htmlNodeToDomChildNode(c)
 }
 
 func removeChild(parent *html.Node, node spec.ChildNode) spec.ChildNode {
 	n := domNodeToHTMLNode(node)
-	parent.RemoveChild(n)
+	if n.Parent != parent {
+		panic("dom: not found error: RemoveChild called for a non-child node")
+	}
+	removeHTMLNode(n)
 	return htmlNodeToDomChildNode(n)
 }
 
 func children(parent *html.Node) spec.ElementCollection {
-	return siblingElements{firstChild: parent.FirstChild}
+	return siblingElements{firstChild: treeFirstChild(parent)}
 }
 
 func firstElementChild(node *html.Node) spec.Element {
-	child := node.FirstChild
+	child := treeFirstChild(node)
 	for child != nil {
 		if child.Type == html.ElementNode {
 			return &Element{node: child}
@@ -308,7 +318,7 @@ func firstElementChild(node *html.Node) spec.Element {
 }
 
 func lastElementChild(node *html.Node) spec.Element {
-	child := node.LastChild
+	child := treeLastChild(node)
 	for child != nil {
 		if child.Type == html.ElementNode {
 			return &Element{node: child}
@@ -321,7 +331,7 @@ func lastElementChild(node *html.Node) spec.Element {
 func childElementCount(node *html.Node) int {
 	var (
 		result = 0
-		child  = node.FirstChild
+		child  = treeFirstChild(node)
 	)
 	for child != nil {
 		if child.Type == html.ElementNode {
@@ -333,102 +343,91 @@ func childElementCount(node *html.Node) int {
 }
 
 func prependNodes(node *html.Node, nodes []spec.Node) {
-	for i := range nodes {
-		dn := nodes[len(nodes)-1-i]
-		if fragment, ok := dn.(*DocumentFragment); ok {
-			for _, n := range fragment.nodes {
-				prependHTMLNode(node, n)
-			}
-			continue
-		}
-		n := domNodeToHTMLNode(dn)
-		prependHTMLNode(node, n)
-	}
-}
-
-func prependHTMLNode(node *html.Node, n *html.Node) {
-	fc := node.FirstChild
-	if fc != nil {
-		fc.PrevSibling = n
-		n.NextSibling = fc
-	}
-	n.Parent = node
-	node.FirstChild = n
-
-	if node.LastChild == nil {
-		node.LastChild = n
-	}
+	inserted := convertNodes(node, nodes)
+	insertHTMLNodes(node, inserted, node.FirstChild)
 }
 
 func appendNodes(parent *html.Node, nodes ...spec.Node) {
-	for _, node := range nodes {
-		if fragment, ok := node.(*DocumentFragment); ok {
-			for _, n := range fragment.nodes {
-				parent.AppendChild(n)
-			}
-			continue
-		}
-		n := domNodeToHTMLNode(node)
-		parent.AppendChild(n)
-	}
+	insertHTMLNodes(parent, convertNodes(parent, nodes), nil)
 }
 
 func replaceChildren(parent *html.Node, nodes []spec.Node) {
-	clearChildren(parent)
-	for _, node := range nodes {
-		n := domNodeToHTMLNode(node)
-		parent.AppendChild(n)
+	replaceAllHTMLNodes(parent, convertNodes(parent, nodes))
+}
+
+func clearChildren(node *html.Node) { replaceAllHTMLNodes(node, nil) }
+
+// normalize is based on https://dom.spec.whatwg.org/#dom-node-normalize
+// It removes empty text nodes and merges adjacent text nodes in the descendants of node.
+// The contents of a template element are not descendants, so they are not changed.
+func normalize(node *html.Node) {
+	if !isTemplateElement(node) {
+		normalizeChildren(node)
+	}
+}
+
+func normalizeChildren(node *html.Node) {
+	mutated(node)
+	for c := node.FirstChild; c != nil; {
+		next := c.NextSibling
+		switch {
+		case c.Type != html.TextNode:
+			normalize(c)
+		case c.Data == "":
+			removeHTMLNode(c)
+		default:
+			for next != nil && next.Type == html.TextNode {
+				rangesMergedText(c, next)
+				appendData(c, next.Data)
+				following := next.NextSibling
+				removeHTMLNode(next)
+				next = following
+			}
+		}
+		c = next
 	}
 }
 
-func clearChildren(node *html.Node) {
-	if fc := node.FirstChild; fc != nil {
-		fc.Parent = nil
+// getElementsByTagName is based on https://dom.spec.whatwg.org/#concept-getelementsbytagname
+// HTML elements match the lower-cased name. Other elements match the qualified name exactly.
+func getElementsByTagName(node *html.Node, qualifiedName string) *liveElements {
+	if qualifiedName == "*" {
+		return newLiveElements(node, func(*html.Node) bool { return true })
 	}
-	if lc := node.LastChild; lc != nil {
-		lc.Parent = nil
-	}
-	node.FirstChild = nil
-	node.LastChild = nil
+	lowerName := strings.ToLower(qualifiedName)
+	return newLiveElements(node, func(n *html.Node) bool {
+		if isInHTMLNamespace(n) {
+			return n.Data == lowerName
+		}
+		return n.Data == qualifiedName
+	})
 }
 
-func getElementsByTagName(node *html.Node, name string) elementList {
-	name = strings.ToUpper(name)
-	var list elementList
-	walkNodes(node, func(n *html.Node) bool {
-		if strings.ToUpper(n.Data) == name {
-			list = append(list, n)
-		}
-		return false
+// getElementsByTagNameNS is based on https://dom.spec.whatwg.org/#concept-getelementsbytagnamens
+// The empty string is the null namespace and "*" matches any namespace or local name.
+func getElementsByTagNameNS(node *html.Node, namespace, localName string) *liveElements {
+	return newLiveElements(node, func(n *html.Node) bool {
+		return (namespace == "*" || elementNamespaceURI(n) == namespace) &&
+			(localName == "*" || elementLocalName(n) == localName)
 	})
-	return list
 }
 
-func getElementsByClassName(node *html.Node, name string) elementList {
-	var list elementList
-	walkNodes(node, func(n *html.Node) bool {
-		if hasClasses(getAttribute(n, "class"), name) {
-			list = append(list, n)
-		}
-		return false
+// getElementsByClassName is based on https://dom.spec.whatwg.org/#concept-getelementsbyclassname
+func getElementsByClassName(node *html.Node, classNames string) *liveElements {
+	classes := strings.Fields(classNames)
+	return newLiveElements(node, func(n *html.Node) bool {
+		return len(classes) > 0 && hasClasses(getAttribute(n, "class"), classes)
 	})
-	return list
 }
 
-func hasClasses(elementClassesStr, classesStr string) bool {
+func hasClasses(elementClassesStr string, classes []string) bool {
 	elementClasses := strings.Fields(elementClassesStr)
-	classes := strings.Fields(classesStr)
-
-	set := make(map[string]struct{}, len(classesStr))
 	for _, c := range classes {
-		set[c] = struct{}{}
-	}
-
-	for _, c := range elementClasses {
-		delete(set, c)
+		if !slices.Contains(elementClasses, c) {
+			return false
+		}
 	}
-
-	return len(set) == 0
+	return true
 }
 
 func querySelector(node *html.Node, query string, includeParent bool) spec.Element {
@@ -436,10 +435,12 @@ func querySelector(node *html.Node, query string, includeParent bool) spec.Eleme
 	if includeParent && q.Match(node) {
 		return &Element{node: node}
 	}
-	if result := cascadia.Query(node, q); result != nil {
-		return &Element{node: result}
-	}
-	return nil
+	var result spec.Element
+	querySelectorSequence(node, q, func(element spec.Element) bool {
+		result = element
+		return false
+	})
+	return result
 }
 
 func querySelectorAll(node *html.Node, query string, includeParent bool) nodeListHTMLElements {
@@ -470,7 +471,7 @@ func (n nodeListHTMLElements) Item(i int) spec.Element {
 
 func closest(node *html.Node, selector string) spec.Element {
 	s := cascadia.MustCompile(selector)
-	for p := node; p != nil; p = p.Parent {
+	for p := node; p != nil; p = treeParent(p) {
 		if s.Match(p) {
 			return htmlNodeToDomElement(p)
 		}
@@ -493,16 +494,53 @@ func isNamed(node *html.Node, name string) bool {
 }
 
 func getAttribute(node *html.Node, name string) string {
-	name = strings.ToLower(name)
-	for _, att := range node.Attr {
-		if att.Key == name {
-			return att.Val
-		}
+	if i := attributeIndex(node, name); i >= 0 {
+		return node.Attr[i].Val
 	}
 	return ""
 }
 
+func setAttribute(node *html.Node, name, value string) {
+	mutated(node)
+	if i := attributeIndex(node, name); i >= 0 {
+		queueAttributeRecord(node, node.Attr[i], node.Attr[i].Val)
+		node.Attr[i].Val = value
+		return
+	}
+	if isInHTMLNamespace(node) {
+		name = strings.ToLower(name)
+	}
+	att := html.Attribute{Key: name, Val: value}
+	queueAttributeRecord(node, att, "")
+	node.Attr = append(node.Attr, att)
+}
+
+func removeAttribute(node *html.Node, name string) {
+	mutated(node)
+	if i := attributeIndex(node, name); i >= 0 {
+		removeAttributeAt(node, i)
+	}
+}
+
+// attributeIndex returns the index of the first attribute with the qualified name or -1.
+// It is based on https://dom.spec.whatwg.org/#concept-element-attributes-get-by-name
+func attributeIndex(node *html.Node, name string) int {
+	if isInHTMLNamespace(node) {
+		name = strings.ToLower(name)
+	}
+	for i, att := range node.Attr {
+		if attributeQualifiedName(att) == name {
+			return i
+		}
+	}
+	return -1
+}
+
+// querySelectorSequence yields the descendants of n that match m. Template contents are not included.
 func querySelectorSequence(n *html.Node, m cascadia.Matcher, yield func(spec.Element) bool) bool {
+	if isTemplateElement(n) {
+		return true
+	}
 	for c := n.FirstChild; c != nil; c = c.NextSibling {
 		if m.Match(c) {
 			if !yield(&Element{node: c}) {
@@ -517,18 +555,26 @@ func querySelectorSequence(n *html.Node, m cascadia.Matcher, yield func(spec.Ele
 }
 
 // compareDocumentPosition is based on https://dom.spec.whatwg.org/#dom-node-comparedocumentposition
-// it does not support attributes
 func compareDocumentPosition(this *html.Node, other spec.Node) spec.DocumentPosition {
 	node1 := domNodeToHTMLNode(other)
 	node2 := this
 	if node1 == node2 {
 		return 0
 	}
-	// attribute nodes not handled
+	if attr, ok := other.(*Attr); ok && attr.index() >= 0 {
+		// an attribute is positioned after its owner element and is never contained by another node
+		if attr.element == this {
+			return spec.DocumentPositionContainedBy | spec.DocumentPositionFollowing
+		}
+		return compareDocumentPosition(this, &Element{node: attr.element}) &^ spec.DocumentPositionContains
+	}
+	if node1 == nil || node2 == nil {
+		return spec.DocumentPositionDisconnected | spec.DocumentPositionImplementationSpecific
+	}
 	owner := ownerDocumentNode(node2)
 	sameRoot := ownerDocumentNode(node1) == owner
 	bothConnected := isConnected(node1) && isConnected(node2)
-	if node1 == nil || node2 == nil || !bothConnected || !sameRoot {
+	if !bothConnected || !sameRoot {
 		// random consistent value for preceding or following is not handled
 		return spec.DocumentPositionDisconnected | spec.DocumentPositionImplementationSpecific
 	}
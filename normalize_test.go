@@ -0,0 +1,73 @@
+package dom_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/typelate/dom"
+	"github.com/typelate/dom/spec"
+)
+
+var (
+	_ spec.Normalizer = (*dom.Element)(nil)
+	_ spec.Normalizer = (*dom.Document)(nil)
+	_ spec.Normalizer = (*dom.DocumentFragment)(nil)
+)
+
+func TestElement_Normalize(t *testing.T) {
+	document := parseAdjacentDocument(t)
+	el := document.CreateElement("div")
+	p := document.CreateElement("p")
+	p.Append(document.CreateTextNode(""), document.CreateTextNode("a"), document.CreateTextNode("b"))
+	el.Append(
+		document.CreateTextNode("Hello"),
+		document.CreateTextNode(""),
+		document.CreateTextNode(", "),
+		document.CreateTextNode("world"),
+		p,
+		document.CreateTextNode(""),
+		document.CreateComment("c"),
+		document.CreateTextNode("!"),
+	)
+	assert.Equal(t, 8, el.ChildNodes().Length())
+
+	el.(spec.Normalizer).Normalize()
+
+	assert.Equal(t, 4, el.ChildNodes().Length())
+	assert.Equal(t, "Hello, world", el.FirstChild().(spec.Text).Data())
+	assert.Equal(t, 1, p.ChildNodes().Length())
+	assert.Equal(t, "ab", p.TextContent())
+	assert.Equal(t, `Hello, world<p>ab</p><!--c-->!`, el.InnerHTML())
+}
+
+func TestDocument_Normalize(t *testing.T) {
+	document := parseAdjacentDocument(t)
+	target := document.QuerySelector("#target")
+	target.Append(document.CreateTextNode("y"), document.CreateTextNode(""), document.CreateTextNode("z"))
+
+	document.(spec.Normalizer).Normalize()
+
+	assert.Equal(t, 2, target.ChildNodes().Length())
+	assert.Equal(t, "yz", target.LastChild().TextContent())
+}
+
+func TestDocumentFragment_Normalize(t *testing.T) {
+	var document *dom.Document
+	fragment := document.CreateDocumentFragment()
+	p := document.CreateElement("p")
+	p.Append(document.CreateTextNode("a"), document.CreateTextNode("b"))
+	fragment.Append(
+		document.CreateTextNode(""),
+		document.CreateTextNode("1"),
+		document.CreateTextNode("2"),
+		p,
+		document.CreateTextNode("3"),
+		document.CreateTextNode(""),
+	)
+
+	fragment.(spec.Normalizer).Normalize()
+
+	assert.Equal(t, `12<p>ab</p>3`, fragment.(*dom.DocumentFragment).String())
+	assert.Equal(t, 1, p.ChildNodes().Length())
+}
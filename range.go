@@ -0,0 +1,666 @@
+package dom
+
+import (
+	"cmp"
+	"strings"
+
+	"golang.org/x/net/html"
+
+	"github.com/typelate/dom/spec"
+)
+
+// Ranges use html.Node boundary point containers. A DocumentFragment does not have an html.Node,
+// so it can not be a container, and nodes at the top level of a fragment are treated as roots.
+
+// boundaryPoint is based on https://dom.spec.whatwg.org/#concept-range-bp
+type boundaryPoint struct {
+	node   *html.Node
+	offset int
+}
+
+// abstractRange is based on https://dom.spec.whatwg.org/#interface-abstractrange
+type abstractRange struct {
+	start, end boundaryPoint
+}
+
+func (r *abstractRange) StartContainer() spec.Node { return NewNode(r.start.node) }
+func (r *abstractRange) StartOffset() int          { return r.start.offset }
+func (r *abstractRange) EndContainer() spec.Node   { return NewNode(r.end.node) }
+func (r *abstractRange) EndOffset() int            { return r.end.offset }
+func (r *abstractRange) Collapsed() bool           { return r.start == r.end }
+
+// staticRange is based on https://dom.spec.whatwg.org/#interface-staticrange
+type staticRange struct {
+	abstractRange
+}
+
+var _ spec.StaticRange = (*staticRange)(nil)
+
+// NewStaticRange is based on https://dom.spec.whatwg.org/#dom-staticrange-staticrange
+// The offsets are not validated and the range is not updated when the tree is mutated.
+func NewStaticRange(startContainer spec.Node, startOffset int, endContainer spec.Node, endOffset int) spec.StaticRange {
+	start, end := rangeNode(startContainer), rangeNode(endContainer)
+	if start.Type == html.DoctypeNode || end.Type == html.DoctypeNode {
+		panic("dom: invalid node type error: a DocumentType can not be a boundary point container")
+	}
+	return &staticRange{abstractRange{
+		start: boundaryPoint{node: start, offset: startOffset},
+		end:   boundaryPoint{node: end, offset: endOffset},
+	}}
+}
+
+// liveRange is based on https://dom.spec.whatwg.org/#interface-range
+type liveRange struct {
+	abstractRange
+}
+
+var _ spec.Range = (*liveRange)(nil)
+
+// liveRanges holds every live range so mutations can update their boundary points.
+var liveRanges liveSet[liveRange]
+
+func newLiveRange(start, end boundaryPoint) *liveRange {
+	r := &liveRange{abstractRange{start: start, end: end}}
+	liveRanges.add(r)
+	return r
+}
+
+func (r *liveRange) root() *html.Node { return rootNode(r.start.node) }
+
+func (r *liveRange) boundaryPoints() []*boundaryPoint { return []*boundaryPoint{&r.start, &r.end} }
+
+func (r *liveRange) CommonAncestorContainer() spec.Node {
+	return NewNode(commonAncestor(r.start.node, r.end.node))
+}
+
+func (r *liveRange) SetStart(node spec.Node, offset int) {
+	r.setStart(boundaryPoint{node: rangeNode(node), offset: offset})
+}
+
+func (r *liveRange) SetEnd(node spec.Node, offset int) {
+	r.setEnd(boundaryPoint{node: rangeNode(node), offset: offset})
+}
+
+func (r *liveRange) SetStartBefore(node spec.Node) { r.setStart(pointBefore(rangeNode(node))) }
+func (r *liveRange) SetStartAfter(node spec.Node)  { r.setStart(pointAfter(rangeNode(node))) }
+func (r *liveRange) SetEndBefore(node spec.Node)   { r.setEnd(pointBefore(rangeNode(node))) }
+func (r *liveRange) SetEndAfter(node spec.Node)    { r.setEnd(pointAfter(rangeNode(node))) }
+
+// setStart is based on https://dom.spec.whatwg.org/#concept-range-bp-set
+func (r *liveRange) setStart(bp boundaryPoint) {
+	checkBoundaryPoint(bp)
+	if r.root() != rootNode(bp.node) || boundaryPointPosition(bp, r.end) > 0 {
+		r.end = bp
+	}
+	r.start = bp
+}
+
+// setEnd is based on https://dom.spec.whatwg.org/#concept-range-bp-set
+func (r *liveRange) setEnd(bp boundaryPoint) {
+	checkBoundaryPoint(bp)
+	if r.root() != rootNode(bp.node) || boundaryPointPosition(bp, r.start) < 0 {
+		r.start = bp
+	}
+	r.end = bp
+}
+
+// Collapse is based on https://dom.spec.whatwg.org/#dom-range-collapse
+func (r *liveRange) Collapse(toStart bool) {
+	if toStart {
+		r.end = r.start
+	} else {
+		r.start = r.end
+	}
+}
+
+// SelectNode is based on https://dom.spec.whatwg.org/#concept-range-select
+func (r *liveRange) SelectNode(node spec.Node) {
+	n := rangeNode(node)
+	r.start, r.end = pointBefore(n), pointAfter(n)
+}
+
+// SelectNodeContents is based on https://dom.spec.whatwg.org/#dom-range-selectnodecontents
+func (r *liveRange) SelectNodeContents(node spec.Node) {
+	n := rangeNode(node)
+	if n.Type == html.DoctypeNode {
+		panic("dom: invalid node type error: a DocumentType can not be a boundary point container")
+	}
+	r.start, r.end = boundaryPoint{node: n}, boundaryPoint{node: n, offset: nodeLength(n)}
+}
+
+// CompareBoundaryPoints is based on https://dom.spec.whatwg.org/#dom-range-compareboundarypoints
+func (r *liveRange) CompareBoundaryPoints(how spec.RangeCompare, sourceRange spec.Range) int {
+	sourceStart := boundaryPoint{node: rangeNode(sourceRange.StartContainer()), offset: sourceRange.StartOffset()}
+	sourceEnd := boundaryPoint{node: rangeNode(sourceRange.EndContainer()), offset: sourceRange.EndOffset()}
+	var this, other boundaryPoint
+	switch how {
+	case spec.RangeStartToStart:
+		this, other = r.start, sourceStart
+	case spec.RangeStartToEnd:
+		this, other = r.end, sourceStart
+	case spec.RangeEndToEnd:
+		this, other = r.end, sourceEnd
+	case spec.RangeEndToStart:
+		this, other = r.start, sourceEnd
+	default:
+		panic("dom: not supported error: unknown range comparison")
+	}
+	if r.root() != rootNode(sourceStart.node) {
+		panic("dom: wrong document error: the ranges do not have the same root")
+	}
+	return boundaryPointPosition(this, other)
+}
+
+// DeleteContents is based on https://dom.spec.whatwg.org/#dom-range-deletecontents
+func (r *liveRange) DeleteContents() {
+	bp := deleteRangeContents(r.start, r.end)
+	r.start, r.end = bp, bp
+}
+
+// ExtractContents is based on https://dom.spec.whatwg.org/#concept-range-extract
+func (r *liveRange) ExtractContents() spec.DocumentFragment {
+	if r.Collapsed() {
+		return &DocumentFragment{}
+	}
+	bp := collapsedRangePoint(r.start, r.end)
+	nodes := rangeContents(r.start, r.end, true)
+	r.start, r.end = bp, bp
+	return &DocumentFragment{nodes: nodes}
+}
+
+// CloneContents is based on https://dom.spec.whatwg.org/#concept-range-clone
+func (r *liveRange) CloneContents() spec.DocumentFragment {
+	return &DocumentFragment{nodes: rangeContents(r.start, r.end, false)}
+}
+
+// InsertNode is based on https://dom.spec.whatwg.org/#concept-range-insert
+func (r *liveRange) InsertNode(node spec.Node) {
+	start := r.start.node
+	fragment, isFragment := node.(*DocumentFragment)
+	var n *html.Node
+	if !isFragment {
+		n = rangeNode(node)
+	}
+	if start.Type == html.CommentNode || (start.Type == html.TextNode && start.Parent == nil) || start == n {
+		panic("dom: hierarchy request error: the node can not be inserted at the start of the range")
+	}
+	reference := start
+	if start.Type != html.TextNode {
+		reference = childAt(start, r.start.offset)
+	}
+	parent := start
+	if reference != nil {
+		parent = reference.Parent
+	}
+	if parent.Type == html.DoctypeNode || (n != nil && n.Type == html.DocumentNode) {
+		panic("dom: hierarchy request error: the node can not be inserted into the parent")
+	}
+	if n != nil && isInclusiveAncestorNode(n, parent) {
+		panic("dom: hierarchy request error: a node can not be inserted into itself or its descendants")
+	}
+	if start.Type == html.TextNode {
+		reference = splitText(start, r.start.offset)
+	}
+	if n != nil && reference == n {
+		reference = n.NextSibling
+	}
+	count := 1
+	if isFragment {
+		count = len(fragment.children())
+	}
+	nodes := convertNodes(parent, []spec.Node{node})
+	newOffset := nodeLength(parent)
+	if reference != nil {
+		newOffset = nodeIndex(reference)
+	}
+	insertHTMLNodes(parent, nodes, reference)
+	if r.Collapsed() {
+		r.end = boundaryPoint{node: parent, offset: newOffset + count}
+	}
+}
+
+// SurroundContents is based on https://dom.spec.whatwg.org/#dom-range-surroundcontents
+func (r *liveRange) SurroundContents(newParent spec.Node) {
+	for _, node := range []*html.Node{r.start.node, r.end.node} {
+		for n := node; n != nil; n = n.Parent {
+			if n.Type != html.TextNode && isPartiallyContained(n, r.start, r.end) {
+				panic("dom: invalid state error: the range partially contains a non-Text node")
+			}
+		}
+	}
+	switch newParent.(type) {
+	case *Document, *DocumentType, *DocumentFragment:
+		panic("dom: invalid node type error: the new parent can not be a Document, DocumentType, or DocumentFragment")
+	}
+	parent := rangeNode(newParent)
+	fragment := r.ExtractContents().(*DocumentFragment)
+	clearChildren(parent)
+	r.InsertNode(newParent)
+	insertHTMLNodes(parent, convertNodes(parent, []spec.Node{fragment}), nil)
+	r.SelectNode(newParent)
+}
+
+// CloneRange is based on https://dom.spec.whatwg.org/#dom-range-clonerange
+func (r *liveRange) CloneRange() spec.Range { return newLiveRange(r.start, r.end) }
+
+// IsPointInRange is based on https://dom.spec.whatwg.org/#dom-range-ispointinrange
+func (r *liveRange) IsPointInRange(node spec.Node, offset int) bool {
+	bp := boundaryPoint{node: rangeNode(node), offset: offset}
+	if rootNode(bp.node) != r.root() {
+		return false
+	}
+	checkBoundaryPoint(bp)
+	return boundaryPointPosition(bp, r.start) >= 0 && boundaryPointPosition(bp, r.end) <= 0
+}
+
+// ComparePoint is based on https://dom.spec.whatwg.org/#dom-range-comparepoint
+func (r *liveRange) ComparePoint(node spec.Node, offset int) int {
+	bp := boundaryPoint{node: rangeNode(node), offset: offset}
+	if rootNode(bp.node) != r.root() {
+		panic("dom: wrong document error: the node does not have the same root as the range")
+	}
+	checkBoundaryPoint(bp)
+	switch {
+	case boundaryPointPosition(bp, r.start) < 0:
+		return -1
+	case boundaryPointPosition(bp, r.end) > 0:
+		return 1
+	default:
+		return 0
+	}
+}
+
+// IntersectsNode is based on https://dom.spec.whatwg.org/#dom-range-intersectsnode
+func (r *liveRange) IntersectsNode(node spec.Node) bool {
+	if _, ok := node.(*DocumentFragment); ok {
+		return false
+	}
+	n := domNodeToHTMLNode(node)
+	if n == nil || rootNode(n) != r.root() {
+		return false
+	}
+	if n.Parent == nil {
+		return true
+	}
+	return boundaryPointPosition(pointBefore(n), r.end) < 0 && boundaryPointPosition(pointAfter(n), r.start) > 0
+}
+
+// String is based on https://dom.spec.whatwg.org/#dom-range-stringifier
+func (r *liveRange) String() string {
+	start, end := r.start, r.end
+	if start.node == end.node && start.node.Type == html.TextNode {
+		return substringData(start.node, start.offset, end.offset-start.offset)
+	}
+	var sb strings.Builder
+	if start.node.Type == html.TextNode {
+		sb.WriteString(substringData(start.node, start.offset, -1))
+	}
+	for n := range commonAncestor(start.node, end.node).Descendants() {
+		if n.Type == html.TextNode && isContained(n, start, end) {
+			sb.WriteString(n.Data)
+		}
+	}
+	if end.node.Type == html.TextNode {
+		sb.WriteString(substringData(end.node, 0, end.offset))
+	}
+	return sb.String()
+}
+
+// rangeNode returns the html.Node of a node used in a range.
+func rangeNode(node spec.Node) *html.Node {
+	if _, ok := node.(*DocumentFragment); ok {
+		panic("dom: not supported error: ranges in a DocumentFragment are not supported")
+	}
+	n := domNodeToHTMLNode(node)
+	if n == nil {
+		panic("dom: invalid node type error: an Attr can not be used in a range")
+	}
+	return n
+}
+
+// checkBoundaryPoint panics when bp can not be a boundary point of a live range.
+func checkBoundaryPoint(bp boundaryPoint) {
+	if bp.node.Type == html.DoctypeNode {
+		panic("dom: invalid node type error: a DocumentType can not be a boundary point container")
+	}
+	if bp.offset < 0 || bp.offset > nodeLength(bp.node) {
+		panic("dom: index size error: boundary point offset is out of range")
+	}
+}
+
+func pointBefore(node *html.Node) boundaryPoint {
+	if node.Parent == nil {
+		panic("dom: invalid node type error: the node does not have a parent")
+	}
+	return boundaryPoint{node: node.Parent, offset: nodeIndex(node)}
+}
+
+func pointAfter(node *html.Node) boundaryPoint {
+	bp := pointBefore(node)
+	bp.offset++
+	return bp
+}
+
+// nodeLength is based on https://dom.spec.whatwg.org/#concept-node-length
+func nodeLength(node *html.Node) int {
+	switch node.Type {
+	case html.DoctypeNode:
+		return 0
+	case html.TextNode, html.CommentNode:
+		return utf16Length(node.Data)
+	}
+	n := 0
+	for range node.ChildNodes() {
+		n++
+	}
+	return n
+}
+
+// nodeIndex is based on https://dom.spec.whatwg.org/#concept-tree-index
+func nodeIndex(node *html.Node) int {
+	i := 0
+	for c := node.PrevSibling; c != nil; c = c.PrevSibling {
+		i++
+	}
+	return i
+}
+
+func childAt(parent *html.Node, index int) *html.Node {
+	c := parent.FirstChild
+	for ; c != nil && index > 0; index-- {
+		c = c.NextSibling
+	}
+	return c
+}
+
+func rootNode(node *html.Node) *html.Node {
+	for node.Parent != nil {
+		node = node.Parent
+	}
+	return node
+}
+
+func isInclusiveAncestorNode(ancestor, node *html.Node) bool {
+	for ; node != nil; node = node.Parent {
+		if node == ancestor {
+			return true
+		}
+	}
+	return false
+}
+
+func isCharacterData(node *html.Node) bool {
+	return node.Type == html.TextNode || node.Type == html.CommentNode
+}
+
+func commonAncestor(a, b *html.Node) *html.Node {
+	for !isInclusiveAncestorNode(a, b) {
+		a = a.Parent
+	}
+	return a
+}
+
+// precedes reports whether node is before other in tree order. Both nodes must have the same root.
+func precedes(node, other *html.Node) bool {
+	position := compareDocumentPosition(other, NewNode(node))
+	if position&spec.DocumentPositionDisconnected == 0 {
+		return position&spec.DocumentPositionPreceding != 0
+	}
+	// compareDocumentPosition does not order nodes outside a document
+	root := rootNode(node)
+	if node == root || other == root {
+		return node == root
+	}
+	for n := range root.Descendants() {
+		switch n {
+		case node:
+			return true
+		case other:
+			return false
+		}
+	}
+	return false
+}
+
+// boundaryPointPosition is based on https://dom.spec.whatwg.org/#concept-range-bp-position
+// It returns -1, 0, or 1 when a is before, equal to, or after b.
+func boundaryPointPosition(a, b boundaryPoint) int {
+	if a.node == b.node {
+		return cmp.Compare(a.offset, b.offset)
+	}
+	if precedes(b.node, a.node) {
+		return -boundaryPointPosition(b, a)
+	}
+	if isInclusiveAncestorNode(a.node, b.node) {
+		child := b.node
+		for child.Parent != a.node {
+			child = child.Parent
+		}
+		if nodeIndex(child) < a.offset {
+			return 1
+		}
+	}
+	return -1
+}
+
+// isContained is based on https://dom.spec.whatwg.org/#contained
+func isContained(node *html.Node, start, end boundaryPoint) bool {
+	return rootNode(node) == rootNode(start.node) &&
+		boundaryPointPosition(boundaryPoint{node: node}, start) > 0 &&
+		boundaryPointPosition(boundaryPoint{node: node, offset: nodeLength(node)}, end) < 0
+}
+
+// isPartiallyContained is based on https://dom.spec.whatwg.org/#partially-contained
+func isPartiallyContained(node *html.Node, start, end boundaryPoint) bool {
+	return isInclusiveAncestorNode(node, start.node) != isInclusiveAncestorNode(node, end.node)
+}
+
+// collapsedRangePoint returns the boundary point a range collapses to when its contents are removed.
+func collapsedRangePoint(start, end boundaryPoint) boundaryPoint {
+	if isInclusiveAncestorNode(start.node, end.node) {
+		return start
+	}
+	reference := start.node
+	for reference.Parent != nil && !isInclusiveAncestorNode(reference.Parent, end.node) {
+		reference = reference.Parent
+	}
+	return pointAfter(reference)
+}
+
+// deleteRangeContents is based on https://dom.spec.whatwg.org/#dom-range-deletecontents
+// It returns the boundary point the range collapses to.
+func deleteRangeContents(start, end boundaryPoint) boundaryPoint {
+	if start == end {
+		return start
+	}
+	if start.node == end.node && isCharacterData(start.node) {
+		replaceData(start.node, start.offset, end.offset-start.offset, "")
+		return start
+	}
+	var nodesToRemove []*html.Node
+	var collect func(parent *html.Node)
+	collect = func(parent *html.Node) {
+		for c := parent.FirstChild; c != nil; c = c.NextSibling {
+			if isContained(c, start, end) {
+				nodesToRemove = append(nodesToRemove, c)
+			} else if isPartiallyContained(c, start, end) {
+				collect(c)
+			}
+		}
+	}
+	collect(commonAncestor(start.node, end.node))
+	bp := collapsedRangePoint(start, end)
+	if isCharacterData(start.node) {
+		replaceData(start.node, start.offset, -1, "")
+	}
+	for _, n := range nodesToRemove {
+		removeHTMLNode(n)
+	}
+	if isCharacterData(end.node) {
+		replaceData(end.node, 0, end.offset, "")
+	}
+	return bp
+}
+
+// rangeContents is based on https://dom.spec.whatwg.org/#concept-range-extract
+// and https://dom.spec.whatwg.org/#concept-range-clone
+// The contents are removed from the tree when extract is true and copied otherwise.
+func rangeContents(start, end boundaryPoint, extract bool) []*html.Node {
+	if start == end {
+		return nil
+	}
+	if start.node == end.node && isCharacterData(start.node) {
+		clone := cloneNode(start.node, false)
+		clone.Data = substringData(start.node, start.offset, end.offset-start.offset)
+		if extract {
+			replaceData(start.node, start.offset, end.offset-start.offset, "")
+		}
+		return []*html.Node{clone}
+	}
+	common := commonAncestor(start.node, end.node)
+	var firstPartiallyContained, lastPartiallyContained *html.Node
+	var containedChildren []*html.Node
+	for c := common.FirstChild; c != nil; c = c.NextSibling {
+		switch {
+		case isContained(c, start, end):
+			if c.Type == html.DoctypeNode {
+				panic("dom: hierarchy request error: the range contains a DocumentType")
+			}
+			containedChildren = append(containedChildren, c)
+		case !isPartiallyContained(c, start, end):
+		case firstPartiallyContained == nil && !isInclusiveAncestorNode(start.node, end.node):
+			firstPartiallyContained = c
+		case !isInclusiveAncestorNode(end.node, start.node):
+			lastPartiallyContained = c
+		}
+	}
+	var nodes []*html.Node
+	if first := firstPartiallyContained; first != nil {
+		clone := cloneNode(first, false)
+		nodes = append(nodes, clone)
+		if isCharacterData(first) {
+			clone.Data = substringData(start.node, start.offset, -1)
+			if extract {
+				replaceData(start.node, start.offset, -1, "")
+			}
+		} else {
+			insertHTMLNodes(clone, rangeContents(start, boundaryPoint{node: first, offset: nodeLength(first)}, extract), nil)
+		}
+	}
+	for _, c := range containedChildren {
+		if extract {
+			removeHTMLNode(c)
+			nodes = append(nodes, c)
+		} else {
+			nodes = append(nodes, cloneNode(c, true))
+		}
+	}
+	if last := lastPartiallyContained; last != nil {
+		clone := cloneNode(last, false)
+		nodes = append(nodes, clone)
+		if isCharacterData(last) {
+			clone.Data = substringData(end.node, 0, end.offset)
+			if extract {
+				replaceData(end.node, 0, end.offset, "")
+			}
+		} else {
+			insertHTMLNodes(clone, rangeContents(boundaryPoint{node: last}, end, extract), nil)
+		}
+	}
+	return nodes
+}
+
+// rangesInserted is based on the live range steps in https://dom.spec.whatwg.org/#concept-node-insert
+func rangesInserted(parent, node *html.Node) {
+	index := -1
+	liveRanges.each(func(r *liveRange) {
+		for _, bp := range r.boundaryPoints() {
+			if bp.node != parent {
+				continue
+			}
+			if index < 0 {
+				index = nodeIndex(node)
+			}
+			if bp.offset > index {
+				bp.offset++
+			}
+		}
+	})
+}
+
+// rangesPreRemove is based on the live range steps in https://dom.spec.whatwg.org/#concept-node-remove
+func rangesPreRemove(node *html.Node) {
+	parent, index := node.Parent, -1
+	liveRanges.each(func(r *liveRange) {
+		for _, bp := range r.boundaryPoints() {
+			if bp.node != parent && !isInclusiveAncestorNode(node, bp.node) {
+				continue
+			}
+			if index < 0 {
+				index = nodeIndex(node)
+			}
+			if bp.node != parent {
+				*bp = boundaryPoint{node: parent, offset: index}
+			} else if bp.offset > index {
+				bp.offset--
+			}
+		}
+	})
+}
+
+// rangesReplacedData is based on the live range steps in https://dom.spec.whatwg.org/#concept-cd-replace
+// The offset, count, and length of the new data are in UTF-16 code units.
+func rangesReplacedData(node *html.Node, offset, count, length int) {
+	liveRanges.each(func(r *liveRange) {
+		for _, bp := range r.boundaryPoints() {
+			switch {
+			case bp.node != node || bp.offset <= offset:
+			case bp.offset <= offset+count:
+				bp.offset = offset
+			default:
+				bp.offset += length - count
+			}
+		}
+	})
+}
+
+// rangesSplitText is based on the live range steps in https://dom.spec.whatwg.org/#concept-text-split
+func rangesSplitText(node, newNode *html.Node, offset int) {
+	parent, index := node.Parent, -1
+	liveRanges.each(func(r *liveRange) {
+		for _, bp := range r.boundaryPoints() {
+			switch {
+			case bp.node == node && bp.offset > offset:
+				*bp = boundaryPoint{node: newNode, offset: bp.offset - offset}
+			case bp.node == parent:
+				if index < 0 {
+					index = nodeIndex(node)
+				}
+				if bp.offset == index+1 {
+					bp.offset++
+				}
+			}
+		}
+	})
+}
+
+// rangesMergedText is based on the live range steps in https://dom.spec.whatwg.org/#dom-node-normalize
+// It runs before the data of next is appended to node.
+func rangesMergedText(node, next *html.Node) {
+	length, index := utf16Length(node.Data), -1
+	liveRanges.each(func(r *liveRange) {
+		for _, bp := range r.boundaryPoints() {
+			switch {
+			case bp.node == next:
+				*bp = boundaryPoint{node: node, offset: bp.offset + length}
+			case next.Parent != nil && bp.node == next.Parent:
+				if index < 0 {
+					index = nodeIndex(next)
+				}
+				if bp.offset == index {
+					*bp = boundaryPoint{node: node, offset: length}
+				}
+			}
+		}
+	})
+}
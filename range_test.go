@@ -0,0 +1,480 @@
+package dom_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/net/html"
+
+	"github.com/typelate/dom"
+	"github.com/typelate/dom/spec"
+)
+
+func parseRangeDocument(t *testing.T, body string) (spec.Document, spec.Element) {
+	t.Helper()
+	node, err := html.Parse(strings.NewReader(`<!DOCTYPE html><html><head></head><body><div id="root">` + body + `</div></body></html>`))
+	require.NoError(t, err)
+	document := dom.NewNode(node).(spec.Document)
+	return document, document.GetElementById("root")
+}
+
+func firstText(t *testing.T, element spec.Element) spec.Text {
+	t.Helper()
+	text, ok := element.FirstChild().(spec.Text)
+	require.True(t, ok)
+	return text
+}
+
+func TestDocument_CreateRange(t *testing.T) {
+	document, _ := parseRangeDocument(t, ``)
+	r := document.CreateRange()
+	assert.True(t, r.StartContainer().IsSameNode(document))
+	assert.True(t, r.EndContainer().IsSameNode(document))
+	assert.Equal(t, 0, r.StartOffset())
+	assert.Equal(t, 0, r.EndOffset())
+	assert.True(t, r.Collapsed())
+	assert.True(t, r.CommonAncestorContainer().IsSameNode(document))
+}
+
+func TestRange_SetStartAndEnd(t *testing.T) {
+	t.Run("contents", func(t *testing.T) {
+		document, root := parseRangeDocument(t, `<p id="a">hello</p><p id="b">world</p>`)
+		a, b := document.GetElementById("a"), document.GetElementById("b")
+		r := document.CreateRange()
+		r.SetStart(firstText(t, a), 1)
+		r.SetEnd(firstText(t, b), 3)
+		assert.False(t, r.Collapsed())
+		assert.True(t, r.CommonAncestorContainer().IsSameNode(root))
+		assert.Equal(t, "ellowor", r.String())
+	})
+	t.Run("end before start collapses", func(t *testing.T) {
+		document, root := parseRangeDocument(t, `<p>one</p><p>two</p>`)
+		r := document.CreateRange()
+		r.SetStart(root, 2)
+		r.SetEnd(root, 1)
+		assert.True(t, r.Collapsed())
+		assert.Equal(t, 1, r.StartOffset())
+	})
+	t.Run("start after end collapses", func(t *testing.T) {
+		document, root := parseRangeDocument(t, `<p>one</p><p>two</p>`)
+		r := document.CreateRange()
+		r.SetEnd(root, 1)
+		r.SetStart(root.LastElementChild(), 0)
+		assert.True(t, r.Collapsed())
+		assert.True(t, r.EndContainer().IsSameNode(root.LastElementChild()))
+	})
+	t.Run("before and after", func(t *testing.T) {
+		document, root := parseRangeDocument(t, `<p id="a">one</p><p id="b">two</p><p id="c">three</p>`)
+		r := document.CreateRange()
+		r.SetStartAfter(document.GetElementById("a"))
+		r.SetEndBefore(document.GetElementById("c"))
+		assert.True(t, r.StartContainer().IsSameNode(root))
+		assert.Equal(t, 1, r.StartOffset())
+		assert.Equal(t, 2, r.EndOffset())
+		assert.Equal(t, "two", r.String())
+
+		r.SetStartBefore(document.GetElementById("a"))
+		r.SetEndAfter(document.GetElementById("c"))
+		assert.Equal(t, 0, r.StartOffset())
+		assert.Equal(t, 3, r.EndOffset())
+		assert.Equal(t, "onetwothree", r.String())
+	})
+	t.Run("offset in UTF-16 code units", func(t *testing.T) {
+		document, root := parseRangeDocument(t, `a😀b`)
+		r := document.CreateRange()
+		r.SetStart(firstText(t, root), 1)
+		r.SetEnd(firstText(t, root), 3)
+		assert.Equal(t, "😀", r.String())
+	})
+	t.Run("index size error", func(t *testing.T) {
+		document, root := parseRangeDocument(t, `<p>one</p>`)
+		r := document.CreateRange()
+		assert.PanicsWithValue(t, "dom: index size error: boundary point offset is out of range", func() {
+			r.SetStart(root, 2)
+		})
+		assert.Panics(t, func() {
+			r.SetEnd(firstText(t, root.FirstElementChild()), 4)
+		})
+	})
+	t.Run("doctype", func(t *testing.T) {
+		document, _ := parseRangeDocument(t, ``)
+		r := document.CreateRange()
+		assert.PanicsWithValue(t, "dom: invalid node type error: a DocumentType can not be a boundary point container", func() {
+			r.SetStart(document.Doctype(), 0)
+		})
+	})
+	t.Run("fragment", func(t *testing.T) {
+		document, _ := parseRangeDocument(t, ``)
+		r := document.CreateRange()
+		assert.PanicsWithValue(t, "dom: not supported error: ranges in a DocumentFragment are not supported", func() {
+			r.SetStart(document.CreateDocumentFragment(), 0)
+		})
+	})
+}
+
+func TestRange_Collapse(t *testing.T) {
+	document, root := parseRangeDocument(t, `<p>one</p><p>two</p>`)
+	r := document.CreateRange()
+	r.SelectNodeContents(root)
+
+	clone := r.CloneRange()
+	clone.Collapse(true)
+	assert.Equal(t, 0, clone.EndOffset())
+
+	r.Collapse(false)
+	assert.Equal(t, 2, r.StartOffset())
+	assert.True(t, r.Collapsed())
+}
+
+func TestRange_SelectNode(t *testing.T) {
+	document, root := parseRangeDocument(t, `<p>one</p><p id="b">two</p>`)
+	r := document.CreateRange()
+	r.SelectNode(document.GetElementById("b"))
+	assert.True(t, r.StartContainer().IsSameNode(root))
+	assert.Equal(t, 1, r.StartOffset())
+	assert.Equal(t, 2, r.EndOffset())
+
+	r.SelectNodeContents(document.GetElementById("b"))
+	assert.Equal(t, 0, r.StartOffset())
+	assert.Equal(t, 1, r.EndOffset())
+
+	r.SelectNodeContents(firstText(t, document.GetElementById("b")))
+	assert.Equal(t, 3, r.EndOffset())
+
+	assert.PanicsWithValue(t, "dom: invalid node type error: the node does not have a parent", func() {
+		r.SelectNode(document)
+	})
+}
+
+func TestRange_CompareBoundaryPoints(t *testing.T) {
+	document, root := parseRangeDocument(t, `<p>one</p><p>two</p><p>three</p>`)
+	r := document.CreateRange()
+	r.SetStart(root, 1)
+	r.SetEnd(root, 2)
+	other := document.CreateRange()
+	other.SetStart(root, 0)
+	other.SetEnd(root, 3)
+
+	assert.Equal(t, 1, r.CompareBoundaryPoints(spec.RangeStartToStart, other))
+	assert.Equal(t, -1, r.CompareBoundaryPoints(spec.RangeEndToEnd, other))
+	assert.Equal(t, 1, r.CompareBoundaryPoints(spec.RangeStartToEnd, other))
+	assert.Equal(t, -1, r.CompareBoundaryPoints(spec.RangeEndToStart, other))
+	assert.Equal(t, 0, r.CompareBoundaryPoints(spec.RangeStartToStart, r.CloneRange()))
+
+	nested := document.CreateRange()
+	nested.SelectNodeContents(root.FirstElementChild())
+	assert.Equal(t, 1, r.CompareBoundaryPoints(spec.RangeStartToStart, nested))
+	assert.Equal(t, -1, nested.CompareBoundaryPoints(spec.RangeStartToStart, r))
+
+	detached := document.CreateElement("div")
+	disconnected := document.CreateRange()
+	disconnected.SelectNodeContents(detached)
+	assert.PanicsWithValue(t, "dom: wrong document error: the ranges do not have the same root", func() {
+		r.CompareBoundaryPoints(spec.RangeStartToStart, disconnected)
+	})
+}
+
+func TestRange_ComparePoint(t *testing.T) {
+	document, root := parseRangeDocument(t, `<p id="a">one</p><p id="b">two</p><p id="c">three</p>`)
+	r := document.CreateRange()
+	r.SelectNode(document.GetElementById("b"))
+
+	assert.Equal(t, -1, r.ComparePoint(firstText(t, document.GetElementById("a")), 1))
+	assert.Equal(t, 0, r.ComparePoint(firstText(t, document.GetElementById("b")), 1))
+	assert.Equal(t, 1, r.ComparePoint(firstText(t, document.GetElementById("c")), 1))
+	assert.True(t, r.IsPointInRange(root, 1))
+	assert.True(t, r.IsPointInRange(root, 2))
+	assert.False(t, r.IsPointInRange(root, 3))
+	assert.False(t, r.IsPointInRange(document.CreateElement("div"), 0))
+
+	assert.True(t, r.IntersectsNode(document.GetElementById("b")))
+	assert.True(t, r.IntersectsNode(root))
+	assert.False(t, r.IntersectsNode(document.GetElementById("a")))
+	assert.False(t, r.IntersectsNode(document.GetElementById("c")))
+	assert.True(t, r.IntersectsNode(document))
+	assert.False(t, r.IntersectsNode(document.CreateElement("div")))
+	assert.False(t, r.IntersectsNode(document.CreateDocumentFragment()))
+
+	assert.Panics(t, func() {
+		r.ComparePoint(document.CreateElement("div"), 0)
+	})
+}
+
+func TestRange_CloneContents(t *testing.T) {
+	document, root := parseRangeDocument(t, `<p id="a">hello</p><hr><p id="b">world</p>`)
+	r := document.CreateRange()
+	r.SetStart(firstText(t, document.GetElementById("a")), 1)
+	r.SetEnd(firstText(t, document.GetElementById("b")), 3)
+
+	fragment := r.CloneContents()
+	assert.Equal(t, `<p id="a">ello</p><hr/><p id="b">wor</p>`, fragment.(*dom.DocumentFragment).String())
+	assert.Equal(t, `<p id="a">hello</p><hr/><p id="b">world</p>`, root.InnerHTML())
+
+	r.SetEnd(firstText(t, document.GetElementById("a")), 3)
+	assert.Equal(t, `el`, r.CloneContents().(*dom.DocumentFragment).String())
+
+	r.Collapse(true)
+	assert.Equal(t, 0, r.CloneContents().ChildElementCount())
+}
+
+func TestRange_ExtractContents(t *testing.T) {
+	t.Run("across elements", func(t *testing.T) {
+		document, root := parseRangeDocument(t, `<p id="a">hello</p><hr><p id="b">world</p>`)
+		r := document.CreateRange()
+		r.SetStart(firstText(t, document.GetElementById("a")), 1)
+		r.SetEnd(firstText(t, document.GetElementById("b")), 3)
+
+		fragment := r.ExtractContents()
+		assert.Equal(t, `<p id="a">ello</p><hr/><p id="b">wor</p>`, fragment.(*dom.DocumentFragment).String())
+		assert.Equal(t, `<p id="a">h</p><p id="b">ld</p>`, root.InnerHTML())
+		assert.True(t, r.Collapsed())
+		assert.True(t, r.StartContainer().IsSameNode(root))
+		assert.Equal(t, 1, r.StartOffset())
+	})
+	t.Run("start contains end", func(t *testing.T) {
+		document, root := parseRangeDocument(t, `<p>one</p><p><b>two</b>three</p>`)
+		r := document.CreateRange()
+		r.SetStart(root, 0)
+		r.SetEnd(firstText(t, root.LastElementChild().FirstElementChild()), 1)
+
+		fragment := r.ExtractContents()
+		assert.Equal(t, `<p>one</p><p><b>t</b></p>`, fragment.(*dom.DocumentFragment).String())
+		assert.Equal(t, `<p><b>wo</b>three</p>`, root.InnerHTML())
+		assert.True(t, r.StartContainer().IsSameNode(root))
+		assert.Equal(t, 0, r.StartOffset())
+	})
+	t.Run("text", func(t *testing.T) {
+		document, root := parseRangeDocument(t, `hello`)
+		r := document.CreateRange()
+		r.SetStart(firstText(t, root), 1)
+		r.SetEnd(firstText(t, root), 4)
+		assert.Equal(t, `ell`, r.ExtractContents().(*dom.DocumentFragment).String())
+		assert.Equal(t, `ho`, root.InnerHTML())
+		assert.Equal(t, 1, r.EndOffset())
+	})
+	t.Run("doctype", func(t *testing.T) {
+		document, _ := parseRangeDocument(t, ``)
+		r := document.CreateRange()
+		r.SelectNodeContents(document)
+		assert.PanicsWithValue(t, "dom: hierarchy request error: the range contains a DocumentType", func() {
+			r.ExtractContents()
+		})
+	})
+}
+
+func TestRange_DeleteContents(t *testing.T) {
+	t.Run("across elements", func(t *testing.T) {
+		document, root := parseRangeDocument(t, `<p id="a">hello</p><hr><p id="b">world</p>`)
+		r := document.CreateRange()
+		r.SetStart(firstText(t, document.GetElementById("a")), 2)
+		r.SetEnd(firstText(t, document.GetElementById("b")), 2)
+		r.DeleteContents()
+		assert.Equal(t, `<p id="a">he</p><p id="b">rld</p>`, root.InnerHTML())
+		assert.True(t, r.Collapsed())
+		assert.True(t, r.StartContainer().IsSameNode(root))
+		assert.Equal(t, 1, r.StartOffset())
+	})
+	t.Run("children", func(t *testing.T) {
+		document, root := parseRangeDocument(t, `<p>one</p><p>two</p><p>three</p>`)
+		r := document.CreateRange()
+		r.SetStart(root, 1)
+		r.SetEnd(root, 3)
+		r.DeleteContents()
+		assert.Equal(t, `<p>one</p>`, root.InnerHTML())
+		assert.Equal(t, 1, r.StartOffset())
+		assert.Equal(t, 1, r.EndOffset())
+	})
+	t.Run("disconnected", func(t *testing.T) {
+		document, _ := parseRangeDocument(t, ``)
+		div := document.CreateElement("div")
+		div.SetInnerHTML(`<p>one</p><p>two</p>`)
+		r := document.CreateRange()
+		r.SetStart(firstText(t, div.FirstElementChild()), 1)
+		r.SetEnd(firstText(t, div.LastElementChild()), 1)
+		assert.Equal(t, "net", r.String())
+		r.DeleteContents()
+		assert.Equal(t, `<p>o</p><p>wo</p>`, div.InnerHTML())
+	})
+}
+
+func TestRange_InsertNode(t *testing.T) {
+	t.Run("element", func(t *testing.T) {
+		document, root := parseRangeDocument(t, `<p>one</p><p>two</p>`)
+		r := document.CreateRange()
+		r.SetStart(root, 1)
+		r.SetEnd(root, 1)
+		r.InsertNode(document.CreateElement("hr"))
+		assert.Equal(t, `<p>one</p><hr/><p>two</p>`, root.InnerHTML())
+		assert.Equal(t, 1, r.StartOffset())
+		assert.Equal(t, 2, r.EndOffset())
+	})
+	t.Run("splits text", func(t *testing.T) {
+		document, root := parseRangeDocument(t, `<p>hello</p>`)
+		p := root.FirstElementChild()
+		r := document.CreateRange()
+		r.SetStart(firstText(t, p), 2)
+		r.SetEnd(firstText(t, p), 4)
+		r.InsertNode(document.CreateElement("b"))
+		assert.Equal(t, `<p>he<b></b>llo</p>`, root.InnerHTML())
+		assert.Equal(t, 2, r.StartOffset())
+		assert.Equal(t, "ll", r.String())
+		assert.Equal(t, "llo", r.EndContainer().TextContent())
+		assert.Equal(t, 2, r.EndOffset())
+	})
+	t.Run("fragment", func(t *testing.T) {
+		document, root := parseRangeDocument(t, `<p>one</p>`)
+		fragment := document.CreateDocumentFragment()
+		fragment.Append(document.CreateElement("a"), document.CreateElement("b"))
+		r := document.CreateRange()
+		r.SetStart(root, 0)
+		r.SetEnd(root, 0)
+		r.InsertNode(fragment)
+		assert.Equal(t, `<a></a><b></b><p>one</p>`, root.InnerHTML())
+		assert.Equal(t, 0, fragment.ChildElementCount())
+		assert.Equal(t, 2, r.EndOffset())
+	})
+	t.Run("moves a node", func(t *testing.T) {
+		document, root := parseRangeDocument(t, `<p>one</p><p>two</p><p>three</p>`)
+		r := document.CreateRange()
+		r.SetStart(root, 1)
+		r.SetEnd(root, 1)
+		r.InsertNode(root.LastElementChild())
+		assert.Equal(t, `<p>one</p><p>three</p><p>two</p>`, root.InnerHTML())
+		assert.Equal(t, 2, r.EndOffset())
+	})
+	t.Run("hierarchy request error", func(t *testing.T) {
+		document, root := parseRangeDocument(t, `<p>one</p>`)
+		r := document.CreateRange()
+		r.SetStart(root, 0)
+		assert.PanicsWithValue(t, "dom: hierarchy request error: a node can not be inserted into itself or its descendants", func() {
+			r.InsertNode(document.Body())
+		})
+		r.SelectNodeContents(document.CreateComment("note"))
+		assert.Panics(t, func() {
+			r.InsertNode(document.CreateElement("b"))
+		})
+	})
+}
+
+func TestRange_SurroundContents(t *testing.T) {
+	document, root := parseRangeDocument(t, `<p>hello</p>`)
+	p := root.FirstElementChild()
+	r := document.CreateRange()
+	r.SetStart(firstText(t, p), 1)
+	r.SetEnd(firstText(t, p), 4)
+	em := document.CreateElement("em")
+	em.SetInnerHTML(`replaced`)
+	r.SurroundContents(em)
+	assert.Equal(t, `<p>h<em>ell</em>o</p>`, root.InnerHTML())
+	assert.True(t, r.StartContainer().IsSameNode(p))
+	assert.Equal(t, 1, r.StartOffset())
+	assert.Equal(t, 2, r.EndOffset())
+
+	t.Run("partially contained", func(t *testing.T) {
+		document, root := parseRangeDocument(t, `<p>one</p><p>two</p>`)
+		r := document.CreateRange()
+		r.SetStart(firstText(t, root.FirstElementChild()), 1)
+		r.SetEnd(root, 2)
+		assert.PanicsWithValue(t, "dom: invalid state error: the range partially contains a non-Text node", func() {
+			r.SurroundContents(document.CreateElement("div"))
+		})
+	})
+	t.Run("invalid parent", func(t *testing.T) {
+		document, root := parseRangeDocument(t, `<p>one</p>`)
+		r := document.CreateRange()
+		r.SelectNodeContents(root)
+		assert.Panics(t, func() {
+			r.SurroundContents(document.CreateDocumentFragment())
+		})
+	})
+}
+
+func TestRange_live(t *testing.T) {
+	t.Run("insert and remove", func(t *testing.T) {
+		document, root := parseRangeDocument(t, `<p id="a">one</p><p id="b">two</p>`)
+		b := document.GetElementById("b")
+		r := document.CreateRange()
+		r.SelectNode(b)
+
+		root.Prepend(document.CreateElement("hr"))
+		assert.Equal(t, 2, r.StartOffset())
+		assert.Equal(t, 3, r.EndOffset())
+
+		root.Append(document.CreateElement("hr"))
+		assert.Equal(t, 3, r.EndOffset())
+
+		document.GetElementById("a").Remove()
+		assert.Equal(t, 1, r.StartOffset())
+		assert.Equal(t, 2, r.EndOffset())
+
+		r.SelectNodeContents(firstText(t, b))
+		b.Remove()
+		assert.True(t, r.StartContainer().IsSameNode(root))
+		assert.True(t, r.Collapsed())
+		assert.Equal(t, 1, r.StartOffset())
+	})
+	t.Run("character data", func(t *testing.T) {
+		document, root := parseRangeDocument(t, `hello`)
+		text := firstText(t, root)
+		r := document.CreateRange()
+		r.SetStart(text, 2)
+		r.SetEnd(text, 5)
+
+		text.InsertData(0, "ab")
+		assert.Equal(t, 4, r.StartOffset())
+		assert.Equal(t, 7, r.EndOffset())
+
+		text.DeleteData(3, 2)
+		assert.Equal(t, 3, r.StartOffset())
+		assert.Equal(t, 5, r.EndOffset())
+
+		text.SetData("x")
+		assert.Equal(t, 0, r.StartOffset())
+		assert.Equal(t, 0, r.EndOffset())
+	})
+	t.Run("split text", func(t *testing.T) {
+		document, root := parseRangeDocument(t, `hello`)
+		text := firstText(t, root)
+		r := document.CreateRange()
+		r.SetStart(text, 3)
+		r.SetEnd(root, 1)
+
+		next := text.SplitText(2)
+		assert.True(t, r.StartContainer().IsSameNode(next))
+		assert.Equal(t, 1, r.StartOffset())
+		assert.True(t, r.EndContainer().IsSameNode(root))
+		assert.Equal(t, 2, r.EndOffset())
+	})
+	t.Run("normalize", func(t *testing.T) {
+		document, root := parseRangeDocument(t, `he`)
+		text := firstText(t, root)
+		next := document.CreateTextNode("llo")
+		root.Append(next)
+		r := document.CreateRange()
+		r.SetStart(root, 1)
+		r.SetEnd(next, 2)
+
+		root.(spec.Normalizer).Normalize()
+		assert.True(t, r.StartContainer().IsSameNode(text))
+		assert.Equal(t, 2, r.StartOffset())
+		assert.True(t, r.EndContainer().IsSameNode(text))
+		assert.Equal(t, 4, r.EndOffset())
+	})
+}
+
+func TestNewStaticRange(t *testing.T) {
+	document, root := parseRangeDocument(t, `<p>one</p><p>two</p>`)
+	r := dom.NewStaticRange(root, 0, root, 2)
+	assert.True(t, r.StartContainer().IsSameNode(root))
+	assert.Equal(t, 2, r.EndOffset())
+	assert.False(t, r.Collapsed())
+
+	root.FirstElementChild().Remove()
+	assert.Equal(t, 2, r.EndOffset())
+
+	assert.PanicsWithValue(t, "dom: invalid node type error: a DocumentType can not be a boundary point container", func() {
+		dom.NewStaticRange(document.Doctype(), 0, root, 0)
+	})
+}
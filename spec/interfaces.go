@@ -22,7 +22,6 @@ import "iter"
 //
 // The following methods were removed because they do not apply across all relevant node types.
 // - NodeValue (only applies to Text and Attr. The former already has Data and the latter is ignored)
-// - IsEqualNode (has different comparisons for different node types and this makes implementation difficult)
 //
 // The following methods have been added in addition to those documented in the whatwg document.
 // - Length
@@ -30,12 +29,15 @@ type Node interface {
 	NodeType() NodeType
 	CloneNode(deep bool) Node
 	IsSameNode(other Node) bool
+	// IsEqualNode is based on https://dom.spec.whatwg.org/#concept-node-equals
+	IsEqualNode(other Node) bool
 	TextContent() string
 	CompareDocumentPosition(other Node) DocumentPosition
 }
 
 type ChildNode interface {
 	Node
+	EventTarget
 
 	IsConnected() bool
 	OwnerDocument() Document
@@ -45,11 +47,24 @@ type ChildNode interface {
 	NextSibling() ChildNode
 
 	// Length should be based on https://dom.spec.whatwg.org/#concept-node-length
+	// For Text and Comment nodes it counts UTF-16 code units.
 	Length() int
 
-	// LookupPrefix(namespace string)
-	// LookupNamespaceURI(prefix string)
-	// IsDefaultNamespace(namespace string) bool
+	// Before, After, ReplaceWith, and Remove are based on https://dom.spec.whatwg.org/#interface-childnode
+	// They do nothing when the node does not have a parent.
+
+	Before(nodes ...Node)
+	After(nodes ...Node)
+	ReplaceWith(nodes ...Node)
+	Remove()
+
+	// LookupPrefix, LookupNamespaceURI, and IsDefaultNamespace are based on
+	// https://dom.spec.whatwg.org/#dom-node-lookupprefix
+	// An empty string is used for a null namespace or prefix.
+
+	LookupPrefix(namespace string) string
+	LookupNamespaceURI(prefix string) string
+	IsDefaultNamespace(namespace string) bool
 }
 
 // Normalizer may be implemented by a Node and should follow https://dom.spec.whatwg.org/#dom-node-normalize
@@ -57,6 +72,16 @@ type Normalizer interface {
 	Normalize()
 }
 
+// Namespace values are based on https://infra.spec.whatwg.org/#namespaces
+const (
+	NamespaceHTML   = "http://www.w3.org/1999/xhtml"
+	NamespaceMathML = "http://www.w3.org/1998/Math/MathML"
+	NamespaceSVG    = "http://www.w3.org/2000/svg"
+	NamespaceXLink  = "http://www.w3.org/1999/xlink"
+	NamespaceXML    = "http://www.w3.org/XML/1998/namespace"
+	NamespaceXMLNS  = "http://www.w3.org/2000/xmlns/"
+)
+
 type DocumentPosition int
 
 // DocumentPosition is based on const values in
@@ -135,24 +160,68 @@ type Text interface {
 	Data() string
 	SetData(string)
 
-	// Split(n int) Text // CONSIDER: maybe implement this
-	// WholeText() string // CONSIDER: maybe implement this
+	// AppendData, InsertData, DeleteData, ReplaceData, and SubstringData are based on
+	// https://dom.spec.whatwg.org/#interface-characterdata
+	// Offsets and counts are in UTF-16 code units. They panic when offset is greater than Length.
+
+	AppendData(data string)
+	InsertData(offset int, data string)
+	DeleteData(offset, count int)
+	ReplaceData(offset, count int, data string)
+	SubstringData(offset, count int) string
+
+	// SplitText and WholeText are based on https://dom.spec.whatwg.org/#interface-text
+
+	SplitText(offset int) Text
+	WholeText() string
 }
 
 type Document interface {
 	Node
+	EventTarget
 
 	ElementQueries
 
 	CreateElement(localName string) Element
 	CreateElementIs(localName, is string) Element
+	CreateElementNS(namespace, qualifiedName string) Element
 
-	// CreateDocumentFragment() node
-
+	CreateDocumentFragment() DocumentFragment
 	CreateTextNode(text string) Text
+	CreateComment(data string) Comment
+	CreateAttribute(localName string) Attr
+
+	// CreateTreeWalker and CreateNodeIterator are based on https://dom.spec.whatwg.org/#interface-document
+	// The filter may be nil.
+	CreateTreeWalker(root Node, whatToShow WhatToShow, filter NodeFilter) TreeWalker
+	CreateNodeIterator(root Node, whatToShow WhatToShow, filter NodeFilter) NodeIterator
+
+	// CreateRange returns a collapsed range at the start of the document.
+	CreateRange() Range
 
+	// ImportNode is based on https://dom.spec.whatwg.org/#dom-document-importnode
+	ImportNode(node Node, deep bool) Node
+	// AdoptNode is based on https://dom.spec.whatwg.org/#dom-document-adoptnode
+	AdoptNode(node Node) Node
+
+	Doctype() DocumentType
+
+	// GetElementById is based on https://dom.spec.whatwg.org/#dom-nonelementparentnode-getelementbyid
+	GetElementById(id string) Element
+
+	DocumentElement() Element
 	Head() Element
 	Body() Element
+
+	// Title and SetTitle are based on https://html.spec.whatwg.org/multipage/dom.html#document.title
+	Title() string
+	SetTitle(title string)
+
+	Forms() ElementCollection
+	Links() ElementCollection
+	Images() ElementCollection
+	Scripts() ElementCollection
+	Embeds() ElementCollection
 }
 
 // ParentNode is based on https://dom.spec.whatwg.org/#interface-parentnode. It also includes some fields and
@@ -187,6 +256,7 @@ type ElementQueries interface {
 	Contains(other Node) bool
 
 	GetElementsByTagName(name string) ElementCollection
+	GetElementsByTagNameNS(namespace, localName string) ElementCollection
 	GetElementsByClassName(name string) ElementCollection
 
 	QuerySelector(query string) Element
@@ -204,6 +274,11 @@ type Element interface {
 	ChildNode
 	ParentNode
 
+	NamespaceURI() string
+	Prefix() string
+	LocalName() string
+
+	// TagName should return the HTML-uppercased qualified name. See https://dom.spec.whatwg.org/#dom-element-tagname
 	TagName() string
 	ID() string
 	ClassName() string
@@ -212,7 +287,27 @@ type Element interface {
 	SetAttribute(name, value string)
 	RemoveAttribute(name string)
 	ToggleAttribute(name string) bool
+	ToggleAttributeForce(name string, force bool) bool
 	HasAttribute(name string) bool
+	HasAttributes() bool
+	GetAttributeNames() []string
+
+	// The namespace parameters are namespace URIs. An empty string is the null namespace.
+
+	GetAttributeNS(namespace, localName string) string
+	SetAttributeNS(namespace, qualifiedName, value string)
+	RemoveAttributeNS(namespace, localName string)
+	HasAttributeNS(namespace, localName string) bool
+
+	Attributes() NamedNodeMap
+	GetAttributeNode(name string) Attr
+	SetAttributeNode(attr Attr) Attr
+	RemoveAttributeNode(attr Attr) Attr
+
+	ClassList() DOMTokenList
+	RelList() DOMTokenList
+	Dataset() DOMStringMap
+	Style() CSSStyleDeclaration
 
 	Closest(selector string) Element
 	Matches(selector string) bool
@@ -221,6 +316,15 @@ type Element interface {
 	InnerHTML() string
 	SetOuterHTML(s string)
 	OuterHTML() string
+
+	// InsertAdjacentElement, InsertAdjacentText, and InsertAdjacentHTML are based on
+	// https://dom.spec.whatwg.org/#dom-element-insertadjacentelement and
+	// https://html.spec.whatwg.org/multipage/dynamic-markup-insertion.html#dom-element-insertadjacenthtml
+	// The position is one of "beforebegin", "afterbegin", "beforeend", or "afterend".
+
+	InsertAdjacentElement(position string, element Element) Element
+	InsertAdjacentText(position, data string)
+	InsertAdjacentHTML(position, text string)
 }
 
 type InnerTextSetter interface {
@@ -239,8 +343,16 @@ type ElementCollection interface {
 	NamedItem(name string) Element
 }
 
+// HTMLTemplateElement is based on https://html.spec.whatwg.org/multipage/scripting.html#htmltemplateelement
+type HTMLTemplateElement interface {
+	Element
+
+	Content() DocumentFragment
+}
+
 type DocumentFragment interface {
 	Node
+	EventTarget
 
 	Children() ElementCollection
 	FirstElementChild() Element
@@ -251,18 +363,358 @@ type DocumentFragment interface {
 	Prepend(nodes ...Node)
 	ReplaceChildren(nodes ...Node)
 
+	GetElementById(id string) Element
+
 	QuerySelector(query string) Element
 	QuerySelectorAll(query string) NodeList[Element]
 	QuerySelectorIterator
 }
 
 type Comment interface {
-	Node
+	ChildNode
 
 	Data() string
-	SetData() string
+	SetData(string)
+}
+
+// Attr is based on https://dom.spec.whatwg.org/#interface-attr
+type Attr interface {
+	Node
+
+	NamespaceURI() string
+	Prefix() string
+	LocalName() string
+	Name() string
+	Value() string
+	SetValue(value string)
+
+	// OwnerElement returns nil when the attribute is not set on an element.
+	OwnerElement() Element
+}
+
+// NamedNodeMap is based on https://dom.spec.whatwg.org/#interface-namednodemap
+// The attributes are ordered by the order they were added to the element.
+type NamedNodeMap interface {
+	Length() int
+	Item(index int) Attr
+
+	GetNamedItem(qualifiedName string) Attr
+	GetNamedItemNS(namespace, localName string) Attr
+	SetNamedItem(attr Attr) Attr
+	SetNamedItemNS(attr Attr) Attr
+	RemoveNamedItem(qualifiedName string) Attr
+	RemoveNamedItemNS(namespace, localName string) Attr
+}
+
+// DOMTokenList is based on https://dom.spec.whatwg.org/#interface-domtokenlist
+//
+// Toggle with the optional force parameter is provided as ToggleForce.
+type DOMTokenList interface {
+	Length() int
+	Item(index int) string
+	Contains(token string) bool
+	Add(tokens ...string)
+	Remove(tokens ...string)
+	Toggle(token string) bool
+	ToggleForce(token string, force bool) bool
+	Replace(token, newToken string) bool
+
+	Value() string
+	SetValue(value string)
+
+	// Values iterates over the tokens in order.
+	Values() iter.Seq[string]
+}
+
+// DOMStringMap is based on https://html.spec.whatwg.org/multipage/dom.html#domstringmap
+// Names are the camel-cased form of data-* attribute names: data-foo-bar has the name fooBar.
+type DOMStringMap interface {
+	Get(name string) (string, bool)
+	Set(name, value string)
+	Delete(name string)
+
+	// All iterates over names and values in attribute order.
+	All() iter.Seq2[string, string]
+}
+
+// CSSStyleDeclaration is based on https://drafts.csswg.org/cssom/#the-cssstyledeclaration-interface
+// Implementations are not required to expand shorthand properties.
+type CSSStyleDeclaration interface {
+	CSSText() string
+	SetCSSText(text string)
+	Length() int
+
+	// Item returns the property name at index.
+	Item(index int) string
+
+	GetPropertyValue(property string) string
+	GetPropertyPriority(property string) string
+	SetProperty(property, value, priority string)
+	RemoveProperty(property string) string
+}
+
+// DocumentType is based on https://dom.spec.whatwg.org/#interface-documenttype
+type DocumentType interface {
+	ChildNode
+
+	Name() string
+	PublicID() string
+	SystemID() string
 }
 
 type QuerySelectorIterator interface {
 	QuerySelectorSequence(query string) iter.Seq[Element]
 }
+
+// WhatToShow is based on const values in
+// https://dom.spec.whatwg.org/#interface-nodefilter
+type WhatToShow uint32
+
+const (
+	ShowElement WhatToShow = 1 << iota
+	ShowAttribute
+	ShowText
+	ShowCdataSection
+	ShowEntityReference
+	ShowEntity
+	ShowProcessingInstruction
+	ShowComment
+	ShowDocument
+	ShowDocumentType
+	ShowDocumentFragment
+	ShowNotation
+
+	ShowAll WhatToShow = 0xFFFFFFFF
+)
+
+// FilterResult is based on const values in
+// https://dom.spec.whatwg.org/#interface-nodefilter
+type FilterResult int
+
+const (
+	FilterAccept FilterResult = iota + 1
+	FilterReject
+	FilterSkip
+)
+
+// NodeFilter is based on https://dom.spec.whatwg.org/#interface-nodefilter
+type NodeFilter interface {
+	AcceptNode(node Node) FilterResult
+}
+
+// NodeFilterFunc is an adapter to allow the use of ordinary functions as a NodeFilter.
+type NodeFilterFunc func(node Node) FilterResult
+
+func (fn NodeFilterFunc) AcceptNode(node Node) FilterResult { return fn(node) }
+
+// TreeWalker is based on https://dom.spec.whatwg.org/#interface-treewalker
+type TreeWalker interface {
+	Root() Node
+	WhatToShow() WhatToShow
+	Filter() NodeFilter
+	CurrentNode() Node
+	SetCurrentNode(node Node)
+
+	ParentNode() Node
+	FirstChild() Node
+	LastChild() Node
+	PreviousSibling() Node
+	NextSibling() Node
+	PreviousNode() Node
+	NextNode() Node
+}
+
+// NodeIterator is based on https://dom.spec.whatwg.org/#interface-nodeiterator
+// It keeps working when its reference node is removed from the tree.
+type NodeIterator interface {
+	Root() Node
+	ReferenceNode() Node
+	PointerBeforeReferenceNode() bool
+	WhatToShow() WhatToShow
+	Filter() NodeFilter
+
+	NextNode() Node
+	PreviousNode() Node
+}
+
+// AbstractRange is based on https://dom.spec.whatwg.org/#interface-abstractrange
+// Offsets in Text and Comment nodes are in UTF-16 code units. Other offsets count children.
+type AbstractRange interface {
+	StartContainer() Node
+	StartOffset() int
+	EndContainer() Node
+	EndOffset() int
+	Collapsed() bool
+}
+
+// StaticRange is based on https://dom.spec.whatwg.org/#interface-staticrange
+// It is not updated when the tree is mutated.
+type StaticRange interface {
+	AbstractRange
+}
+
+// RangeCompare is based on const values in
+// https://dom.spec.whatwg.org/#interface-range
+type RangeCompare int
+
+const (
+	RangeStartToStart RangeCompare = iota
+	RangeStartToEnd
+	RangeEndToEnd
+	RangeEndToStart
+)
+
+// Range is based on https://dom.spec.whatwg.org/#interface-range
+// It is updated when the tree is mutated. String is based on the stringifier.
+type Range interface {
+	AbstractRange
+
+	CommonAncestorContainer() Node
+
+	SetStart(node Node, offset int)
+	SetEnd(node Node, offset int)
+	SetStartBefore(node Node)
+	SetStartAfter(node Node)
+	SetEndBefore(node Node)
+	SetEndAfter(node Node)
+	Collapse(toStart bool)
+	SelectNode(node Node)
+	SelectNodeContents(node Node)
+
+	CompareBoundaryPoints(how RangeCompare, sourceRange Range) int
+
+	DeleteContents()
+	ExtractContents() DocumentFragment
+	CloneContents() DocumentFragment
+	InsertNode(node Node)
+	SurroundContents(newParent Node)
+
+	CloneRange() Range
+
+	IsPointInRange(node Node, offset int) bool
+	ComparePoint(node Node, offset int) int
+	IntersectsNode(node Node) bool
+
+	String() string
+}
+
+// MutationRecordType is based on the type values in
+// https://dom.spec.whatwg.org/#interface-mutationrecord
+type MutationRecordType string
+
+const (
+	MutationAttributes    MutationRecordType = "attributes"
+	MutationCharacterData MutationRecordType = "characterData"
+	MutationChildList     MutationRecordType = "childList"
+)
+
+// MutationRecord is based on https://dom.spec.whatwg.org/#interface-mutationrecord
+// Null strings and nodes are represented by "" and nil.
+type MutationRecord interface {
+	Type() MutationRecordType
+	Target() Node
+	AddedNodes() NodeList[Node]
+	RemovedNodes() NodeList[Node]
+	PreviousSibling() Node
+	NextSibling() Node
+	AttributeName() string
+	AttributeNamespace() string
+	OldValue() string
+}
+
+// MutationObserverInit is based on https://dom.spec.whatwg.org/#dictdef-mutationobserverinit
+// Attributes is implied by AttributeOldValue or AttributeFilter and
+// CharacterData is implied by CharacterDataOldValue.
+type MutationObserverInit struct {
+	ChildList             bool
+	Attributes            bool
+	CharacterData         bool
+	Subtree               bool
+	AttributeOldValue     bool
+	CharacterDataOldValue bool
+	AttributeFilter       []string
+}
+
+// MutationCallback is based on https://dom.spec.whatwg.org/#callbackdef-mutationcallback
+type MutationCallback func(records []MutationRecord, observer MutationObserver)
+
+// MutationObserver is based on https://dom.spec.whatwg.org/#interface-mutationobserver
+type MutationObserver interface {
+	Observe(target Node, options MutationObserverInit)
+	Disconnect()
+	TakeRecords() []MutationRecord
+}
+
+// MutationObserverFlusher may be implemented by a MutationObserver that is not notified by a microtask queue.
+// Flush calls the callback of the observer with its pending records.
+type MutationObserverFlusher interface {
+	Flush()
+}
+
+// EventTarget is based on https://dom.spec.whatwg.org/#interface-eventtarget
+// Listeners are compared with == when they are added and removed, so they should be comparable values like pointers.
+type EventTarget interface {
+	AddEventListener(eventType string, listener EventListener, options AddEventListenerOptions)
+	RemoveEventListener(eventType string, listener EventListener, options EventListenerOptions)
+	DispatchEvent(event Event) bool
+}
+
+// EventListener is based on https://dom.spec.whatwg.org/#callbackdef-eventlistener
+type EventListener interface {
+	HandleEvent(event Event)
+}
+
+// EventListenerFunc adapts a function to an EventListener.
+// The method has a pointer receiver so that a listener can be compared and removed.
+type EventListenerFunc func(event Event)
+
+func (fn *EventListenerFunc) HandleEvent(event Event) { (*fn)(event) }
+
+// EventListenerOptions is based on https://dom.spec.whatwg.org/#dictdef-eventlisteneroptions
+type EventListenerOptions struct {
+	Capture bool
+}
+
+// AddEventListenerOptions is based on https://dom.spec.whatwg.org/#dictdef-addeventlisteneroptions
+type AddEventListenerOptions struct {
+	Capture bool
+	Once    bool
+	Passive bool
+}
+
+// EventInit is based on https://dom.spec.whatwg.org/#dictdef-eventinit
+type EventInit struct {
+	Bubbles    bool
+	Cancelable bool
+	Composed   bool
+}
+
+// EventPhase is based on const values in
+// https://dom.spec.whatwg.org/#interface-event
+type EventPhase int
+
+const (
+	EventPhaseNone EventPhase = iota
+	EventPhaseCapturing
+	EventPhaseAtTarget
+	EventPhaseBubbling
+)
+
+// Event is based on https://dom.spec.whatwg.org/#interface-event
+type Event interface {
+	Type() string
+	Target() EventTarget
+	CurrentTarget() EventTarget
+	ComposedPath() []EventTarget
+	EventPhase() EventPhase
+
+	StopPropagation()
+	StopImmediatePropagation()
+
+	Bubbles() bool
+	Cancelable() bool
+	Composed() bool
+	PreventDefault()
+	DefaultPrevented() bool
+	IsTrusted() bool
+}
@@ -0,0 +1,230 @@
+package dom
+
+import (
+	"slices"
+	"strings"
+
+	"golang.org/x/net/html"
+
+	"github.com/typelate/dom/spec"
+)
+
+// style is a live view of the declarations in the style attribute of an element.
+// Values are stored as written; they are not normalized and shorthand properties are not expanded.
+type style struct {
+	node *html.Node
+}
+
+var _ spec.CSSStyleDeclaration = style{}
+
+type cssDeclaration struct {
+	property  string
+	value     string
+	important bool
+}
+
+func (s style) declarations() []cssDeclaration {
+	return parseCSSDeclarations(getAttribute(s.node, "style"))
+}
+
+func (s style) update(declarations []cssDeclaration) {
+	setAttribute(s.node, "style", serializeCSSDeclarations(declarations))
+}
+
+func (s style) CSSText() string {
+	return serializeCSSDeclarations(s.declarations())
+}
+
+func (s style) SetCSSText(text string) {
+	s.update(parseCSSDeclarations(text))
+}
+
+func (s style) Length() int { return len(s.declarations()) }
+
+func (s style) Item(index int) string {
+	declarations := s.declarations()
+	if index < 0 || index >= len(declarations) {
+		return ""
+	}
+	return declarations[index].property
+}
+
+func (s style) GetPropertyValue(property string) string {
+	declarations := s.declarations()
+	if i := cssDeclarationIndex(declarations, property); i >= 0 {
+		return declarations[i].value
+	}
+	return ""
+}
+
+func (s style) GetPropertyPriority(property string) string {
+	declarations := s.declarations()
+	if i := cssDeclarationIndex(declarations, property); i >= 0 && declarations[i].important {
+		return "important"
+	}
+	return ""
+}
+
+// SetProperty is based on https://drafts.csswg.org/cssom/#dom-cssstyledeclaration-setproperty
+// An empty value removes the property. A priority other than "" or "important" is ignored.
+func (s style) SetProperty(property, value, priority string) {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		s.RemoveProperty(property)
+		return
+	}
+	if priority != "" && !strings.EqualFold(priority, "important") {
+		return
+	}
+	declaration := cssDeclaration{
+		property:  normalizeCSSPropertyName(property),
+		value:     value,
+		important: priority != "",
+	}
+	declarations := s.declarations()
+	if i := cssDeclarationIndex(declarations, property); i >= 0 {
+		declarations[i] = declaration
+	} else {
+		declarations = append(declarations, declaration)
+	}
+	s.update(declarations)
+}
+
+func (s style) RemoveProperty(property string) string {
+	declarations := s.declarations()
+	i := cssDeclarationIndex(declarations, property)
+	if i < 0 {
+		return ""
+	}
+	value := declarations[i].value
+	s.update(slices.Delete(declarations, i, i+1))
+	return value
+}
+
+func cssDeclarationIndex(declarations []cssDeclaration, property string) int {
+	property = normalizeCSSPropertyName(property)
+	return slices.IndexFunc(declarations, func(d cssDeclaration) bool {
+		return d.property == property
+	})
+}
+
+// normalizeCSSPropertyName lower-cases property names except for custom properties.
+func normalizeCSSPropertyName(property string) string {
+	property = strings.TrimSpace(property)
+	if strings.HasPrefix(property, "--") {
+		return property
+	}
+	return strings.ToLower(property)
+}
+
+func serializeCSSDeclarations(declarations []cssDeclaration) string {
+	var sb strings.Builder
+	for i, d := range declarations {
+		if i > 0 {
+			sb.WriteByte(' ')
+		}
+		sb.WriteString(d.property)
+		sb.WriteString(": ")
+		sb.WriteString(d.value)
+		if d.important {
+			sb.WriteString(" !important")
+		}
+		sb.WriteByte(';')
+	}
+	return sb.String()
+}
+
+// parseCSSDeclarations parses a declaration list like the one in a style attribute.
+// Semicolons and colons inside strings, parentheses, or comments do not split declarations.
+// When a property is declared more than once, the last declaration wins unless an earlier one is important.
+func parseCSSDeclarations(text string) []cssDeclaration {
+	var declarations []cssDeclaration
+	for _, part := range splitCSS(text, ';') {
+		nameAndValue := splitCSS(part, ':')
+		if len(nameAndValue) < 2 {
+			continue
+		}
+		property := normalizeCSSPropertyName(stripCSSComments(nameAndValue[0]))
+		value := strings.TrimSpace(stripCSSComments(strings.Join(nameAndValue[1:], ":")))
+		if property == "" || value == "" {
+			continue
+		}
+		d := cssDeclaration{property: property, value: value}
+		if before, found := cutSuffixFold(value, "important"); found {
+			if v, ok := strings.CutSuffix(strings.TrimSpace(before), "!"); ok {
+				d.value = strings.TrimSpace(v)
+				d.important = true
+			}
+		}
+		if d.value == "" {
+			continue
+		}
+		if i := cssDeclarationIndex(declarations, property); i >= 0 {
+			if declarations[i].important && !d.important {
+				continue
+			}
+			declarations = slices.Delete(declarations, i, i+1)
+		}
+		declarations = append(declarations, d)
+	}
+	return declarations
+}
+
+func cutSuffixFold(s, suffix string) (string, bool) {
+	if len(s) < len(suffix) || !strings.EqualFold(s[len(s)-len(suffix):], suffix) {
+		return s, false
+	}
+	return s[:len(s)-len(suffix)], true
+}
+
+// splitCSS splits text on sep when it is not inside a string, parentheses, or a comment.
+func splitCSS(text string, sep byte) []string {
+	var (
+		parts []string
+		depth int
+		quote byte
+		start int
+	)
+	for i := 0; i < len(text); i++ {
+		c := text[i]
+		switch {
+		case quote != 0:
+			if c == '\\' {
+				i++
+			} else if c == quote {
+				quote = 0
+			}
+		case c == '/' && i+1 < len(text) && text[i+1] == '*':
+			end := strings.Index(text[i+2:], "*/")
+			if end < 0 {
+				i = len(text)
+			} else {
+				i += end + 3
+			}
+		case c == '"' || c == '\'':
+			quote = c
+		case c == '(':
+			depth++
+		case c == ')' && depth > 0:
+			depth--
+		case c == sep && depth == 0:
+			parts = append(parts, text[start:i])
+			start = i + 1
+		}
+	}
+	return append(parts, text[start:])
+}
+
+func stripCSSComments(text string) string {
+	for {
+		start := strings.Index(text, "/*")
+		if start < 0 {
+			return text
+		}
+		end := strings.Index(text[start+2:], "*/")
+		if end < 0 {
+			return text[:start]
+		}
+		text = text[:start] + text[start+2+end+2:]
+	}
+}
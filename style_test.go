@@ -0,0 +1,85 @@
+package dom
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestElement_Style(t *testing.T) {
+	newElement := func(t *testing.T, style string) *Element {
+		t.Helper()
+		// language=html
+		_, el := parseDocument(t, `<!DOCTYPE html><html><head></head><body><p id="a" style="`+style+`"></p></body></html>`, "#a")
+		return el
+	}
+
+	t.Run("GetPropertyValue", func(t *testing.T) {
+		style := newElement(t, "color: darkred;").Style()
+		assert.Equal(t, "darkred", style.GetPropertyValue("color"))
+		assert.Equal(t, "darkred", style.GetPropertyValue("COLOR"))
+		assert.Zero(t, style.GetPropertyValue("margin"))
+		assert.Zero(t, style.GetPropertyPriority("color"))
+		assert.Equal(t, 1, style.Length())
+		assert.Equal(t, "color", style.Item(0))
+		assert.Zero(t, style.Item(1))
+	})
+	t.Run("parse", func(t *testing.T) {
+		style := newElement(t, "Margin:0 ;color : red !IMPORTANT; --Brand-Color: #123; background: url('a;b:c.png') no-repeat; /* note */ padding: 1px /* x */; ; broken; color: blue").Style()
+		require.Equal(t, 5, style.Length())
+		assert.Equal(t, "margin", style.Item(0))
+		assert.Equal(t, "0", style.GetPropertyValue("margin"))
+		assert.Equal(t, "red", style.GetPropertyValue("color"))
+		assert.Equal(t, "important", style.GetPropertyPriority("color"))
+		assert.Equal(t, "#123", style.GetPropertyValue("--Brand-Color"))
+		assert.Zero(t, style.GetPropertyValue("--brand-color"))
+		assert.Equal(t, "url('a;b:c.png') no-repeat", style.GetPropertyValue("background"))
+		assert.Equal(t, "1px", style.GetPropertyValue("padding"))
+	})
+	t.Run("last declaration wins", func(t *testing.T) {
+		style := newElement(t, "color: red; margin: 0; color: blue").Style()
+		assert.Equal(t, "margin: 0; color: blue;", style.CSSText())
+	})
+	t.Run("SetProperty", func(t *testing.T) {
+		el := newElement(t, "color: darkred;")
+		style := el.Style()
+
+		style.SetProperty("margin", "0", "")
+		style.SetProperty("color", "red", "important")
+		style.SetProperty("padding", "1px", "invalid")
+
+		assert.Equal(t, "color: red !important; margin: 0;", el.GetAttribute("style"))
+	})
+	t.Run("SetProperty empty value", func(t *testing.T) {
+		el := newElement(t, "color: darkred; margin: 0")
+		el.Style().SetProperty("color", " ", "")
+		assert.Equal(t, "margin: 0;", el.GetAttribute("style"))
+	})
+	t.Run("SetProperty without attribute", func(t *testing.T) {
+		var document *Document
+		el := document.CreateElement("p")
+		el.Style().SetProperty("color", "red", "")
+		assert.Equal(t, `<p style="color: red;"></p>`, el.OuterHTML())
+	})
+	t.Run("RemoveProperty", func(t *testing.T) {
+		el := newElement(t, "color: darkred; margin: 0")
+		assert.Equal(t, "darkred", el.Style().RemoveProperty("color"))
+		assert.Zero(t, el.Style().RemoveProperty("color"))
+		assert.Equal(t, "margin: 0;", el.GetAttribute("style"))
+	})
+	t.Run("CSSText", func(t *testing.T) {
+		el := newElement(t, "color:darkred")
+		style := el.Style()
+		assert.Equal(t, "color: darkred;", style.CSSText())
+
+		style.SetCSSText("margin:0;padding:0 !important")
+		assert.Equal(t, "margin: 0; padding: 0 !important;", el.GetAttribute("style"))
+	})
+	t.Run("live", func(t *testing.T) {
+		el := newElement(t, "color: darkred")
+		style := el.Style()
+		el.SetAttribute("style", "color: green")
+		assert.Equal(t, "green", style.GetPropertyValue("color"))
+	})
+}
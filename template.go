@@ -0,0 +1,106 @@
+package dom
+
+import (
+	"runtime"
+	"sync"
+	"weak"
+
+	"golang.org/x/net/html"
+
+	"github.com/typelate/dom/spec"
+)
+
+// The contents of a template element are the html.Node children of the element
+// because that is how x/net/html parses and renders them. The methods in this package
+// that query or navigate a tree do not descend into a template element, and the top-level
+// content nodes do not have the template as their parent, so the contents behave
+// as the separate tree described in https://html.spec.whatwg.org/multipage/scripting.html#template-contents
+
+var _ spec.HTMLTemplateElement = (*Element)(nil)
+
+// Content is based on https://html.spec.whatwg.org/multipage/scripting.html#dom-template-content
+// It returns nil when the element is not a template element.
+// The fragment is a view of the template contents, so changes to either are reflected in the other.
+func (e *Element) Content() spec.DocumentFragment {
+	if !isTemplateElement(e.node) {
+		return nil
+	}
+	return contentFragment(e.node)
+}
+
+func isTemplateElement(node *html.Node) bool { return isHTMLElement(node, "template") }
+
+// templateContent holds the content of a template element.
+// The fragment refers to the template, so it is held weakly to let the template be collected.
+// A fragment that is no longer referenced is replaced by a new one with the same event listeners.
+type templateContent struct {
+	fragment  weak.Pointer[DocumentFragment]
+	listeners eventListeners
+}
+
+// templateContents holds the content of each template element that has been requested.
+// Templates are weak keys so that the contents do not keep a template alive.
+var templateContents = struct {
+	sync.Mutex
+	contents map[weak.Pointer[html.Node]]*templateContent
+}{contents: make(map[weak.Pointer[html.Node]]*templateContent)}
+
+func lookupTemplateContent(template *html.Node) *templateContent {
+	key := weak.Make(template)
+	content, ok := templateContents.contents[key]
+	if !ok {
+		content = new(templateContent)
+		templateContents.contents[key] = content
+		runtime.AddCleanup(template, func(key weak.Pointer[html.Node]) {
+			templateContents.Lock()
+			defer templateContents.Unlock()
+			delete(templateContents.contents, key)
+		}, key)
+	}
+	return content
+}
+
+// contentFragment returns the DocumentFragment for the contents of template.
+func contentFragment(template *html.Node) *DocumentFragment {
+	templateContents.Lock()
+	defer templateContents.Unlock()
+	content := lookupTemplateContent(template)
+	if fragment := content.fragment.Value(); fragment != nil {
+		return fragment
+	}
+	fragment := &DocumentFragment{template: template}
+	content.fragment = weak.Make(fragment)
+	return fragment
+}
+
+func templateContentListeners(template *html.Node) *eventListeners {
+	templateContents.Lock()
+	defer templateContents.Unlock()
+	return &lookupTemplateContent(template).listeners
+}
+
+// treeParent returns the parent of node in the DOM. It is nil for the top-level
+// nodes of a template element's contents because their parent is the content fragment.
+func treeParent(node *html.Node) *html.Node {
+	if isTemplateElement(node.Parent) {
+		return nil
+	}
+	return node.Parent
+}
+
+// treeFirstChild and treeLastChild return nil for a template element
+// because its html.Node children are the contents.
+
+func treeFirstChild(node *html.Node) *html.Node {
+	if isTemplateElement(node) {
+		return nil
+	}
+	return node.FirstChild
+}
+
+func treeLastChild(node *html.Node) *html.Node {
+	if isTemplateElement(node) {
+		return nil
+	}
+	return node.LastChild
+}
@@ -0,0 +1,154 @@
+package dom_test
+
+import (
+	"runtime"
+	"slices"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/typelate/dom"
+	"github.com/typelate/dom/spec"
+)
+
+func TestElement_Content(t *testing.T) {
+	const body = `<p>before</p><template id="tmpl"><p id="inside" class="item">inside</p><template><em>nested</em></template></template>`
+
+	t.Run("not a template", func(t *testing.T) {
+		_, root := parseMutationDocument(t, body)
+		assert.Nil(t, root.(spec.HTMLTemplateElement).Content())
+	})
+	t.Run("fragment", func(t *testing.T) {
+		document, _ := parseMutationDocument(t, body)
+		template := document.GetElementById("tmpl").(spec.HTMLTemplateElement)
+		content := template.Content()
+		require.NotNil(t, content)
+		assert.True(t, content.IsSameNode(template.Content()))
+		assert.Equal(t, 2, content.ChildElementCount())
+		assert.Equal(t, "inside", content.GetElementById("inside").TextContent())
+		assert.Equal(t, "inside", content.QuerySelector(".item").TextContent())
+		assert.Equal(t, 1, content.QuerySelectorAll("em, p").Length(), "nested template contents are separate")
+	})
+	t.Run("queries", func(t *testing.T) {
+		document, root := parseMutationDocument(t, body)
+		assert.Nil(t, document.QuerySelector(".item"))
+		assert.Nil(t, root.QuerySelector("#inside"))
+		assert.Equal(t, 1, document.QuerySelectorAll("p").Length())
+		assert.Len(t, slices.Collect(root.QuerySelectorSequence("p")), 1)
+		assert.Nil(t, document.GetElementById("inside"))
+		assert.Equal(t, 1, document.GetElementsByTagName("p").Length())
+		assert.Zero(t, root.GetElementsByClassName("item").Length())
+		assert.Equal(t, 2, root.GetElementsByTagName("*").Length())
+		assert.False(t, root.Contains(document.GetElementById("tmpl").(spec.HTMLTemplateElement).Content().FirstElementChild()))
+	})
+	t.Run("TextContent", func(t *testing.T) {
+		document, root := parseMutationDocument(t, body)
+		assert.Equal(t, "before", root.TextContent())
+		template := document.GetElementById("tmpl").(spec.HTMLTemplateElement)
+		assert.Empty(t, template.TextContent())
+		assert.Equal(t, "inside", template.Content().TextContent())
+	})
+	t.Run("changes to the content", func(t *testing.T) {
+		document, _ := parseMutationDocument(t, body)
+		template := document.GetElementById("tmpl").(spec.HTMLTemplateElement)
+		content := template.Content()
+		content.ReplaceChildren(document.CreateElement("hr"))
+		assert.Equal(t, `<template id="tmpl"><hr/></template>`, template.OuterHTML())
+
+		content.Append(document.CreateTextNode("a"), document.CreateTextNode("b"))
+		content.(spec.Normalizer).Normalize()
+		assert.Equal(t, `<hr/>ab`, template.InnerHTML())
+	})
+	t.Run("inserting the content moves it out of the template", func(t *testing.T) {
+		document, root := parseMutationDocument(t, body)
+		template := document.GetElementById("tmpl").(spec.HTMLTemplateElement)
+		root.Append(template.Content())
+		assert.Zero(t, template.Content().ChildElementCount())
+		assert.NotNil(t, document.GetElementById("inside"))
+	})
+	t.Run("CloneNode", func(t *testing.T) {
+		document, _ := parseMutationDocument(t, body)
+		template := document.GetElementById("tmpl").(spec.HTMLTemplateElement)
+
+		deep := template.CloneNode(true).(spec.HTMLTemplateElement)
+		assert.Equal(t, 2, deep.Content().ChildElementCount())
+		assert.True(t, deep.Content().IsEqualNode(template.Content()))
+		assert.False(t, deep.Content().IsSameNode(template.Content()))
+
+		shallow := template.CloneNode(false).(spec.HTMLTemplateElement)
+		assert.Zero(t, shallow.Content().ChildElementCount())
+
+		fragment := template.Content().CloneNode(true).(spec.DocumentFragment)
+		assert.Equal(t, 2, fragment.ChildElementCount())
+		assert.Equal(t, 2, template.Content().ChildElementCount())
+	})
+	t.Run("separate tree", func(t *testing.T) {
+		document, _ := parseMutationDocument(t, body)
+		template := document.GetElementById("tmpl").(spec.HTMLTemplateElement)
+		inside := template.Content().FirstElementChild()
+		assert.Nil(t, inside.ParentNode())
+		assert.Nil(t, inside.ParentElement())
+		assert.False(t, inside.IsConnected())
+		assert.False(t, inside.FirstChild().IsConnected())
+		assert.True(t, template.IsConnected())
+
+		assert.False(t, template.HasChildNodes())
+		assert.Zero(t, template.ChildNodes().Length())
+		assert.Nil(t, template.FirstChild())
+		assert.Nil(t, template.LastChild())
+		assert.Zero(t, template.Children().Length())
+		assert.Zero(t, template.ChildElementCount())
+		assert.Nil(t, template.FirstElementChild())
+		assert.Nil(t, template.LastElementChild())
+
+		assert.Nil(t, inside.Closest("div"))
+		assert.NotNil(t, inside.Closest("p"))
+		template.SetAttributeNS(spec.NamespaceXMLNS, "xmlns:x", "http://example.com/x")
+		assert.Empty(t, inside.LookupNamespaceURI("x"))
+		assert.Equal(t, "http://example.com/x", template.LookupNamespaceURI("x"))
+
+		walker := document.CreateTreeWalker(document, spec.ShowElement, nil)
+		assert.Equal(t, []string{"html", "head", "body", "div", "p", "template"}, collectNext(walker.NextNode))
+		walker = document.CreateTreeWalker(template.Content(), spec.ShowElement, nil)
+		assert.Equal(t, []string{"p", "template"}, collectNext(walker.NextNode))
+	})
+	t.Run("Normalize", func(t *testing.T) {
+		document, _ := parseMutationDocument(t, body)
+		template := document.GetElementById("tmpl").(spec.HTMLTemplateElement)
+		content := template.Content()
+		content.ReplaceChildren(document.CreateTextNode("a"), document.CreateTextNode("b"))
+
+		texts := func() []string {
+			return collectNext(document.CreateTreeWalker(content, spec.ShowText, nil).NextNode)
+		}
+		template.(spec.Normalizer).Normalize()
+		assert.Equal(t, []string{"#a", "#b"}, texts())
+		content.(spec.Normalizer).Normalize()
+		assert.Equal(t, []string{"#ab"}, texts())
+	})
+	t.Run("event listeners persist", func(t *testing.T) {
+		document, _ := parseMutationDocument(t, body)
+		template := document.GetElementById("tmpl").(spec.HTMLTemplateElement)
+		assert.Same(t, template.Content(), template.Content())
+
+		var log []string
+		template.Content().AddEventListener("x", recordListener(&log, "content"), spec.AddEventListenerOptions{})
+		runtime.GC()
+		template.Content().DispatchEvent(dom.NewEvent("x", spec.EventInit{}))
+		assert.Equal(t, []string{"content:2"}, log)
+	})
+	t.Run("events bubble to the content", func(t *testing.T) {
+		document, _ := parseMutationDocument(t, body)
+		template := document.GetElementById("tmpl").(spec.HTMLTemplateElement)
+		inside := template.Content().FirstElementChild()
+		var log []string
+		document.AddEventListener("x", recordListener(&log, "document"), spec.AddEventListenerOptions{})
+		template.AddEventListener("x", recordListener(&log, "template"), spec.AddEventListenerOptions{})
+		template.Content().AddEventListener("x", recordListener(&log, "content"), spec.AddEventListenerOptions{})
+		inside.AddEventListener("x", recordListener(&log, "inside"), spec.AddEventListenerOptions{})
+
+		inside.FirstChild().DispatchEvent(dom.NewEvent("x", spec.EventInit{Bubbles: true}))
+		assert.Equal(t, []string{"inside:3", "content:3"}, log)
+	})
+}
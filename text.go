@@ -1,6 +1,8 @@
 package dom
 
 import (
+	"strings"
+
 	"golang.org/x/net/html"
 
 	"github.com/typelate/dom/spec"
@@ -11,16 +13,51 @@ type Text struct {
 }
 
 func (t *Text) Data() string     { return t.node.Data }
-func (t *Text) SetData(d string) { t.node.Data = d }
+func (t *Text) SetData(d string) { setData(t.node, d) }
+
+func (t *Text) AppendData(data string)             { appendData(t.node, data) }
+func (t *Text) InsertData(offset int, data string) { replaceData(t.node, offset, 0, data) }
+func (t *Text) DeleteData(offset, count int)       { replaceData(t.node, offset, count, "") }
+
+func (t *Text) ReplaceData(offset, count int, data string) {
+	replaceData(t.node, offset, count, data)
+}
+
+func (t *Text) SubstringData(offset, count int) string {
+	return substringData(t.node, offset, count)
+}
+
+// SplitText is based on https://dom.spec.whatwg.org/#dom-text-splittext
+// The new node is inserted after t when t has a parent.
+func (t *Text) SplitText(offset int) spec.Text {
+	return &Text{node: splitText(t.node, offset)}
+}
+
+// WholeText is based on https://dom.spec.whatwg.org/#dom-text-wholetext
+func (t *Text) WholeText() string {
+	start := t.node
+	for start.PrevSibling != nil && start.PrevSibling.Type == html.TextNode {
+		start = start.PrevSibling
+	}
+	var sb strings.Builder
+	for n := start; n != nil && n.Type == html.TextNode; n = n.NextSibling {
+		sb.WriteString(n.Data)
+	}
+	return sb.String()
+}
 
 func (t *Text) NodeType() spec.NodeType         { return nodeType(t.node.Type) }
 func (t *Text) IsConnected() bool               { return isConnected(t.node) }
 func (t *Text) OwnerDocument() spec.Document    { return ownerDocument(t.node) }
-func (t *Text) Length() int                     { return len(t.node.Data) }
+func (t *Text) Length() int                     { return utf16Length(t.node.Data) }
 func (t *Text) ParentNode() spec.Node           { return parentNode(t.node) }
 func (t *Text) ParentElement() spec.Element     { return parentElement(t.node) }
 func (t *Text) PreviousSibling() spec.ChildNode { return previousSibling(t.node) }
 func (t *Text) NextSibling() spec.ChildNode     { return nextSibling(t.node) }
+func (t *Text) Before(nodes ...spec.Node)       { before(t.node, nodes) }
+func (t *Text) After(nodes ...spec.Node)        { after(t.node, nodes) }
+func (t *Text) ReplaceWith(nodes ...spec.Node)  { replaceWith(t.node, nodes) }
+func (t *Text) Remove()                         { remove(t.node) }
 func (t *Text) TextContent() string             { return t.node.Data }
 func (t *Text) CloneNode(_ bool) spec.Node {
 	return &Text{
@@ -31,10 +68,23 @@ func (t *Text) CloneNode(_ bool) spec.Node {
 	}
 }
 
+func (t *Text) LookupPrefix(namespace string) string {
+	return lookupPrefix(t.node, namespace)
+}
+
+func (t *Text) LookupNamespaceURI(prefix string) string {
+	return locateNamespace(t.node, prefix)
+}
+
+func (t *Text) IsDefaultNamespace(namespace string) bool {
+	return isDefaultNamespace(t.node, namespace)
+}
+
 func (t *Text) CompareDocumentPosition(other spec.Node) spec.DocumentPosition {
 	return compareDocumentPosition(t.node, other)
 }
 
-func (t *Text) IsSameNode(other spec.Node) bool { return isSameNode(t.node, other) }
+func (t *Text) IsSameNode(other spec.Node) bool  { return isSameNode(t.node, other) }
+func (t *Text) IsEqualNode(other spec.Node) bool { return isEqualNode(t, other) }
 
 func (t *Text) String() string { return t.node.Data }
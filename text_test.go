@@ -222,3 +222,104 @@ func TestText_IsSameNode(t *testing.T) {
 		node: p.node.FirstChild,
 	}))
 }
+
+func TestText_Length_utf16(t *testing.T) {
+	for _, tt := range []struct {
+		Data   string
+		Length int
+	}{
+		{Data: "", Length: 0},
+		{Data: "peach", Length: 5},
+		{Data: "café", Length: 4},
+		{Data: "🍑", Length: 2},
+	} {
+		t.Run(tt.Data, func(t *testing.T) {
+			textNode := &Text{node: &html.Node{Type: html.TextNode, Data: tt.Data}}
+			assert.Equal(t, tt.Length, textNode.Length())
+		})
+	}
+}
+
+func TestText_CharacterData(t *testing.T) {
+	newText := func(data string) *Text {
+		return &Text{node: &html.Node{Type: html.TextNode, Data: data}}
+	}
+	t.Run("append", func(t *testing.T) {
+		textNode := newText("🍑")
+		textNode.AppendData(" pie")
+		assert.Equal(t, "🍑 pie", textNode.Data())
+	})
+	t.Run("insert", func(t *testing.T) {
+		textNode := newText("🍑pie")
+		textNode.InsertData(2, " ")
+		assert.Equal(t, "🍑 pie", textNode.Data())
+		textNode.InsertData(textNode.Length(), "!")
+		assert.Equal(t, "🍑 pie!", textNode.Data())
+	})
+	t.Run("delete", func(t *testing.T) {
+		textNode := newText("café 🍑 pie")
+		textNode.DeleteData(5, 3)
+		assert.Equal(t, "café pie", textNode.Data())
+		textNode.DeleteData(4, 100)
+		assert.Equal(t, "café", textNode.Data())
+	})
+	t.Run("replace", func(t *testing.T) {
+		textNode := newText("🍑 pie")
+		textNode.ReplaceData(0, 2, "peach")
+		assert.Equal(t, "peach pie", textNode.Data())
+	})
+	t.Run("substring", func(t *testing.T) {
+		textNode := newText("a🍑b")
+		assert.Equal(t, "🍑", textNode.SubstringData(1, 2))
+		assert.Equal(t, "b", textNode.SubstringData(3, 10))
+		assert.Equal(t, "", textNode.SubstringData(4, 1))
+	})
+	t.Run("out of range", func(t *testing.T) {
+		textNode := newText("🍑")
+		assert.Panics(t, func() { textNode.SubstringData(3, 1) })
+		assert.Panics(t, func() { textNode.InsertData(-1, "x") })
+		assert.Panics(t, func() { textNode.DeleteData(3, 1) })
+		assert.Panics(t, func() { textNode.ReplaceData(3, 1, "x") })
+	})
+}
+
+func TestText_SplitText(t *testing.T) {
+	t.Run("attached", func(t *testing.T) {
+		// language=html
+		_, p := parseDocument(t, `<!DOCTYPE html><html><head></head><body><p>café🍑pie<br></p></body></html>`, "p")
+		textNode := &Text{node: p.node.FirstChild}
+
+		result := textNode.SplitText(6)
+
+		assert.Equal(t, "café🍑", textNode.Data())
+		assert.Equal(t, "pie", result.Data())
+		assert.True(t, result.PreviousSibling().IsSameNode(textNode))
+		assert.Equal(t, 3, p.ChildNodes().Length())
+		assert.Equal(t, "café🍑pie", textNode.WholeText())
+		assert.Equal(t, "café🍑pie", result.WholeText())
+	})
+	t.Run("detached", func(t *testing.T) {
+		textNode := &Text{node: &html.Node{Type: html.TextNode, Data: "peach"}}
+		result := textNode.SplitText(2)
+		assert.Equal(t, "pe", textNode.Data())
+		assert.Equal(t, "ach", result.Data())
+		assert.Nil(t, result.ParentNode())
+	})
+	t.Run("out of range", func(t *testing.T) {
+		textNode := &Text{node: &html.Node{Type: html.TextNode, Data: "peach"}}
+		assert.Panics(t, func() { textNode.SplitText(6) })
+	})
+}
+
+func TestText_WholeText(t *testing.T) {
+	// language=html
+	_, p := parseDocument(t, `<!DOCTYPE html><html><head></head><body><p>a<br>b</p></body></html>`, "p")
+	b := p.node.LastChild
+	b.Parent.InsertBefore(&html.Node{Type: html.TextNode, Data: "c"}, nil)
+	b.Parent.InsertBefore(&html.Node{Type: html.CommentNode, Data: "x"}, nil)
+	b.Parent.InsertBefore(&html.Node{Type: html.TextNode, Data: "d"}, nil)
+
+	assert.Equal(t, "a", (&Text{node: p.node.FirstChild}).WholeText())
+	assert.Equal(t, "bc", (&Text{node: b}).WholeText())
+	assert.Equal(t, "d", (&Text{node: p.node.LastChild}).WholeText())
+}
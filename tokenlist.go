@@ -0,0 +1,147 @@
+package dom
+
+import (
+	"iter"
+	"slices"
+	"strings"
+
+	"golang.org/x/net/html"
+
+	"github.com/typelate/dom/spec"
+)
+
+// domTokenList is a live view of the ordered set of tokens in an attribute.
+// It is based on https://dom.spec.whatwg.org/#interface-domtokenlist
+type domTokenList struct {
+	node *html.Node
+	name string
+}
+
+var _ spec.DOMTokenList = domTokenList{}
+
+func (list domTokenList) tokens() []string {
+	return orderedSet(getAttribute(list.node, list.name))
+}
+
+// update is based on https://dom.spec.whatwg.org/#concept-dtl-update
+func (list domTokenList) update(tokens []string) {
+	if attributeIndex(list.node, list.name) < 0 && len(tokens) == 0 {
+		return
+	}
+	setAttribute(list.node, list.name, strings.Join(tokens, " "))
+}
+
+func (list domTokenList) Length() int { return len(list.tokens()) }
+
+func (list domTokenList) Item(index int) string {
+	tokens := list.tokens()
+	if index < 0 || index >= len(tokens) {
+		return ""
+	}
+	return tokens[index]
+}
+
+func (list domTokenList) Contains(token string) bool {
+	return slices.Contains(list.tokens(), token)
+}
+
+func (list domTokenList) Add(tokens ...string) {
+	validateTokens(tokens...)
+	set := list.tokens()
+	for _, token := range tokens {
+		if !slices.Contains(set, token) {
+			set = append(set, token)
+		}
+	}
+	list.update(set)
+}
+
+func (list domTokenList) Remove(tokens ...string) {
+	validateTokens(tokens...)
+	set := slices.DeleteFunc(list.tokens(), func(token string) bool {
+		return slices.Contains(tokens, token)
+	})
+	list.update(set)
+}
+
+func (list domTokenList) Toggle(token string) bool {
+	validateTokens(token)
+	if list.Contains(token) {
+		list.Remove(token)
+		return false
+	}
+	list.Add(token)
+	return true
+}
+
+func (list domTokenList) ToggleForce(token string, force bool) bool {
+	validateTokens(token)
+	if list.Contains(token) != force {
+		list.Toggle(token)
+	}
+	return force
+}
+
+// Replace is based on https://dom.spec.whatwg.org/#dom-domtokenlist-replace
+func (list domTokenList) Replace(token, newToken string) bool {
+	validateTokens(token, newToken)
+	set := list.tokens()
+	index := slices.Index(set, token)
+	if index < 0 {
+		return false
+	}
+	if i := slices.Index(set, newToken); i >= 0 && i < index {
+		index = i
+	}
+	result := make([]string, 0, len(set))
+	for i, t := range set {
+		switch {
+		case i == index:
+			result = append(result, newToken)
+		case t == token || t == newToken:
+		default:
+			result = append(result, t)
+		}
+	}
+	list.update(result)
+	return true
+}
+
+func (list domTokenList) Value() string         { return getAttribute(list.node, list.name) }
+func (list domTokenList) SetValue(value string) { setAttribute(list.node, list.name, value) }
+
+func (list domTokenList) Values() iter.Seq[string] {
+	return slices.Values(list.tokens())
+}
+
+// orderedSet is based on https://dom.spec.whatwg.org/#concept-ordered-set-parser
+func orderedSet(value string) []string {
+	var tokens []string
+	for _, token := range strings.FieldsFunc(value, isASCIIWhitespace) {
+		if !slices.Contains(tokens, token) {
+			tokens = append(tokens, token)
+		}
+	}
+	return tokens
+}
+
+func validateTokens(tokens ...string) {
+	for _, token := range tokens {
+		if token == "" {
+			panic("dom: DOMTokenList token must not be empty")
+		}
+		if strings.ContainsFunc(token, isASCIIWhitespace) {
+			panic("dom: DOMTokenList token must not contain whitespace")
+		}
+	}
+}
+
+// isASCIIWhitespace is based on https://infra.spec.whatwg.org/#ascii-whitespace
+func isASCIIWhitespace(r rune) bool {
+	switch r {
+	case '\t', '\n', '\f', '\r', ' ':
+		return true
+	default:
+		return false
+	}
+}
@@ -0,0 +1,111 @@
+package dom
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestElement_ClassList(t *testing.T) {
+	newElement := func(t *testing.T, class string) *Element {
+		t.Helper()
+		// language=html
+		_, el := parseDocument(t, `<!DOCTYPE html><html><head></head><body><div id="a" class="`+class+`"></div></body></html>`, "#a")
+		return el
+	}
+
+	t.Run("parse", func(t *testing.T) {
+		list := newElement(t, " b  a\tb c ").ClassList()
+		require.Equal(t, 3, list.Length())
+		assert.Equal(t, "b", list.Item(0))
+		assert.Equal(t, "a", list.Item(1))
+		assert.Equal(t, "c", list.Item(2))
+		assert.Zero(t, list.Item(3))
+		assert.Zero(t, list.Item(-1))
+		assert.Equal(t, []string{"b", "a", "c"}, slices.Collect(list.Values()))
+		assert.Equal(t, " b  a\tb c ", list.Value())
+		assert.True(t, list.Contains("a"))
+		assert.False(t, list.Contains("d"))
+	})
+	t.Run("Add", func(t *testing.T) {
+		el := newElement(t, "a  b")
+		el.ClassList().Add("c", "a", "d")
+		assert.Equal(t, "a b c d", el.ClassName())
+	})
+	t.Run("Add without attribute", func(t *testing.T) {
+		var document *Document
+		el := document.CreateElement("div")
+		el.ClassList().Add("a")
+		assert.Equal(t, `<div class="a"></div>`, el.OuterHTML())
+	})
+	t.Run("Remove", func(t *testing.T) {
+		el := newElement(t, "a b c a")
+		el.ClassList().Remove("a", "d")
+		assert.Equal(t, "b c", el.ClassName())
+	})
+	t.Run("Remove without attribute", func(t *testing.T) {
+		var document *Document
+		el := document.CreateElement("div")
+		el.ClassList().Remove("a")
+		assert.False(t, el.HasAttribute("class"))
+	})
+	t.Run("Toggle", func(t *testing.T) {
+		el := newElement(t, "a b")
+		list := el.ClassList()
+		assert.False(t, list.Toggle("a"))
+		assert.Equal(t, "b", el.ClassName())
+		assert.True(t, list.Toggle("a"))
+		assert.Equal(t, "b a", el.ClassName())
+	})
+	t.Run("ToggleForce", func(t *testing.T) {
+		el := newElement(t, "a")
+		list := el.ClassList()
+		assert.True(t, list.ToggleForce("a", true))
+		assert.True(t, list.ToggleForce("b", true))
+		assert.Equal(t, "a b", el.ClassName())
+		assert.False(t, list.ToggleForce("a", false))
+		assert.False(t, list.ToggleForce("c", false))
+		assert.Equal(t, "b", el.ClassName())
+	})
+	t.Run("Replace", func(t *testing.T) {
+		el := newElement(t, "a b c b")
+		list := el.ClassList()
+		assert.True(t, list.Replace("b", "d"))
+		assert.Equal(t, "a d c", el.ClassName())
+		assert.False(t, list.Replace("x", "y"))
+		assert.Equal(t, "a d c", el.ClassName())
+		assert.True(t, list.Replace("c", "a"))
+		assert.Equal(t, "a d", el.ClassName())
+	})
+	t.Run("SetValue", func(t *testing.T) {
+		el := newElement(t, "a")
+		el.ClassList().SetValue("x  y")
+		assert.Equal(t, "x  y", el.ClassName())
+		assert.Equal(t, 2, el.ClassList().Length())
+	})
+	t.Run("live", func(t *testing.T) {
+		el := newElement(t, "a")
+		list := el.ClassList()
+		el.SetAttribute("class", "x y z")
+		assert.Equal(t, 3, list.Length())
+	})
+	t.Run("invalid tokens", func(t *testing.T) {
+		list := newElement(t, "a").ClassList()
+		assert.Panics(t, func() { list.Add("") })
+		assert.Panics(t, func() { list.Remove("a b") })
+		assert.Panics(t, func() { list.Toggle("a\tb") })
+		assert.Panics(t, func() { list.Replace("a", "") })
+	})
+}
+
+func TestElement_RelList(t *testing.T) {
+	// language=html
+	_, link := parseDocument(t, `<!DOCTYPE html><html><head><link rel="preload stylesheet" href="/style.css"></head><body></body></html>`, "link")
+
+	list := link.RelList()
+	assert.True(t, list.Contains("preload"))
+	list.Remove("preload")
+	assert.Equal(t, "stylesheet", link.GetAttribute("rel"))
+}
@@ -0,0 +1,428 @@
+package dom
+
+import (
+	"slices"
+
+	"golang.org/x/net/html"
+
+	"github.com/typelate/dom/spec"
+)
+
+// traversalTree navigates the tree below a root node.
+// A DocumentFragment does not have an html.Node, so a placeholder node is used as the root
+// and the fragment's nodes are treated as its children.
+type traversalTree struct {
+	root     *html.Node
+	fragment *DocumentFragment
+}
+
+func newTraversalTree(root spec.Node) traversalTree {
+	switch r := root.(type) {
+	case nil:
+		panic("dom: traversal root must not be nil")
+	case *DocumentFragment:
+		return traversalTree{root: &html.Node{Type: html.DocumentNode}, fragment: r}
+	case *Attr:
+		panic("dom: traversal of attributes is not supported")
+	default:
+		return traversalTree{root: domNodeToHTMLNode(root)}
+	}
+}
+
+func (t traversalTree) isFragmentChild(node *html.Node) bool {
+	return t.fragment != nil && node.Parent == t.fragment.template && slices.Contains(t.fragment.children(), node)
+}
+
+func (t traversalTree) parent(node *html.Node) *html.Node {
+	if t.isFragmentChild(node) {
+		return t.root
+	}
+	return node.Parent
+}
+
+func (t traversalTree) firstChild(node *html.Node) *html.Node {
+	if t.fragment != nil && node == t.root {
+		nodes := t.fragment.children()
+		if len(nodes) == 0 {
+			return nil
+		}
+		return nodes[0]
+	}
+	return treeFirstChild(node)
+}
+
+func (t traversalTree) lastChild(node *html.Node) *html.Node {
+	if t.fragment != nil && node == t.root {
+		nodes := t.fragment.children()
+		if len(nodes) == 0 {
+			return nil
+		}
+		return nodes[len(nodes)-1]
+	}
+	return treeLastChild(node)
+}
+
+func (t traversalTree) nextSibling(node *html.Node) *html.Node {
+	if t.isFragmentChild(node) {
+		nodes := t.fragment.children()
+		if i := slices.Index(nodes, node); i+1 < len(nodes) {
+			return nodes[i+1]
+		}
+		return nil
+	}
+	return node.NextSibling
+}
+
+func (t traversalTree) previousSibling(node *html.Node) *html.Node {
+	if t.isFragmentChild(node) {
+		nodes := t.fragment.children()
+		if i := slices.Index(nodes, node); i > 0 {
+			return nodes[i-1]
+		}
+		return nil
+	}
+	return node.PrevSibling
+}
+
+func (t traversalTree) isInclusiveAncestor(ancestor, node *html.Node) bool {
+	for ; node != nil; node = t.parent(node) {
+		if node == ancestor {
+			return true
+		}
+	}
+	return false
+}
+
+// following returns the node after node in tree order within the root.
+// When skipChildren is true, the descendants of node are skipped.
+func (t traversalTree) following(node *html.Node, skipChildren bool) *html.Node {
+	if !skipChildren {
+		if c := t.firstChild(node); c != nil {
+			return c
+		}
+	}
+	for ; node != nil && node != t.root; node = t.parent(node) {
+		if s := t.nextSibling(node); s != nil {
+			return s
+		}
+	}
+	return nil
+}
+
+// preceding returns the node before node in tree order within the root.
+func (t traversalTree) preceding(node *html.Node) *html.Node {
+	if node == t.root {
+		return nil
+	}
+	if s := t.previousSibling(node); s != nil {
+		return t.lastInclusiveDescendant(s)
+	}
+	return t.parent(node)
+}
+
+func (t traversalTree) lastInclusiveDescendant(node *html.Node) *html.Node {
+	for c := t.lastChild(node); c != nil; c = t.lastChild(node) {
+		node = c
+	}
+	return node
+}
+
+func (t traversalTree) domNode(node *html.Node) spec.Node {
+	if node == nil {
+		return nil
+	}
+	if t.fragment != nil && node == t.root {
+		return t.fragment
+	}
+	return NewNode(node)
+}
+
+// traversal holds the state shared by TreeWalker and NodeIterator.
+type traversal struct {
+	tree       traversalTree
+	whatToShow spec.WhatToShow
+	filter     spec.NodeFilter
+	active     bool
+}
+
+func (t *traversal) Root() spec.Node             { return t.tree.domNode(t.tree.root) }
+func (t *traversal) WhatToShow() spec.WhatToShow { return t.whatToShow }
+func (t *traversal) Filter() spec.NodeFilter     { return t.filter }
+
+// filterNode is based on https://dom.spec.whatwg.org/#concept-node-filter
+func (t *traversal) filterNode(node *html.Node) spec.FilterResult {
+	if t.active {
+		panic("dom: invalid state error: a node filter must not call the traversal it filters")
+	}
+	n := t.tree.domNode(node)
+	if nt := n.NodeType(); nt < spec.NodeTypeElement || t.whatToShow&(1<<(nt-1)) == 0 {
+		return spec.FilterSkip
+	}
+	if t.filter == nil {
+		return spec.FilterAccept
+	}
+	t.active = true
+	defer func() { t.active = false }()
+	return t.filter.AcceptNode(n)
+}
+
+// treeWalker is based on https://dom.spec.whatwg.org/#interface-treewalker
+type treeWalker struct {
+	traversal
+	current *html.Node
+}
+
+var _ spec.TreeWalker = (*treeWalker)(nil)
+
+func newTreeWalker(root spec.Node, whatToShow spec.WhatToShow, filter spec.NodeFilter) *treeWalker {
+	tree := newTraversalTree(root)
+	return &treeWalker{
+		traversal: traversal{tree: tree, whatToShow: whatToShow, filter: filter},
+		current:   tree.root,
+	}
+}
+
+func (w *treeWalker) CurrentNode() spec.Node { return w.tree.domNode(w.current) }
+
+func (w *treeWalker) SetCurrentNode(node spec.Node) {
+	if fragment, ok := node.(*DocumentFragment); ok && fragment == w.tree.fragment {
+		w.current = w.tree.root
+		return
+	}
+	n := domNodeToHTMLNode(node)
+	if n == nil {
+		panic("dom: TreeWalker current node must be a node in a tree")
+	}
+	w.current = n
+}
+
+func (w *treeWalker) ParentNode() spec.Node {
+	node := w.current
+	for node != nil && node != w.tree.root {
+		node = w.tree.parent(node)
+		if node != nil && w.filterNode(node) == spec.FilterAccept {
+			w.current = node
+			return w.tree.domNode(node)
+		}
+	}
+	return nil
+}
+
+func (w *treeWalker) FirstChild() spec.Node { return w.traverseChildren(true) }
+func (w *treeWalker) LastChild() spec.Node  { return w.traverseChildren(false) }
+
+// traverseChildren is based on https://dom.spec.whatwg.org/#concept-traverse-children
+func (w *treeWalker) traverseChildren(first bool) spec.Node {
+	child, sibling := w.tree.lastChild, w.tree.previousSibling
+	if first {
+		child, sibling = w.tree.firstChild, w.tree.nextSibling
+	}
+	node := child(w.current)
+	for node != nil {
+		result := w.filterNode(node)
+		if result == spec.FilterAccept {
+			w.current = node
+			return w.tree.domNode(node)
+		}
+		if result == spec.FilterSkip {
+			if c := child(node); c != nil {
+				node = c
+				continue
+			}
+		}
+		for node != nil {
+			if s := sibling(node); s != nil {
+				node = s
+				break
+			}
+			parent := w.tree.parent(node)
+			if parent == nil || parent == w.tree.root || parent == w.current {
+				return nil
+			}
+			node = parent
+		}
+	}
+	return nil
+}
+
+func (w *treeWalker) NextSibling() spec.Node     { return w.traverseSiblings(true) }
+func (w *treeWalker) PreviousSibling() spec.Node { return w.traverseSiblings(false) }
+
+// traverseSiblings is based on https://dom.spec.whatwg.org/#concept-traverse-siblings
+func (w *treeWalker) traverseSiblings(next bool) spec.Node {
+	child, sibling := w.tree.lastChild, w.tree.previousSibling
+	if next {
+		child, sibling = w.tree.firstChild, w.tree.nextSibling
+	}
+	node := w.current
+	if node == w.tree.root {
+		return nil
+	}
+	for {
+		s := sibling(node)
+		for s != nil {
+			node = s
+			result := w.filterNode(node)
+			if result == spec.FilterAccept {
+				w.current = node
+				return w.tree.domNode(node)
+			}
+			s = child(node)
+			if result == spec.FilterReject || s == nil {
+				s = sibling(node)
+			}
+		}
+		node = w.tree.parent(node)
+		if node == nil || node == w.tree.root {
+			return nil
+		}
+		if w.filterNode(node) == spec.FilterAccept {
+			return nil
+		}
+	}
+}
+
+// PreviousNode is based on https://dom.spec.whatwg.org/#dom-treewalker-previousnode
+func (w *treeWalker) PreviousNode() spec.Node {
+	node := w.current
+	for node != w.tree.root {
+		for s := w.tree.previousSibling(node); s != nil; s = w.tree.previousSibling(node) {
+			node = s
+			result := w.filterNode(node)
+			for result != spec.FilterReject && w.tree.lastChild(node) != nil {
+				node = w.tree.lastChild(node)
+				result = w.filterNode(node)
+			}
+			if result == spec.FilterAccept {
+				w.current = node
+				return w.tree.domNode(node)
+			}
+		}
+		parent := w.tree.parent(node)
+		if node == w.tree.root || parent == nil {
+			return nil
+		}
+		node = parent
+		if w.filterNode(node) == spec.FilterAccept {
+			w.current = node
+			return w.tree.domNode(node)
+		}
+	}
+	return nil
+}
+
+// NextNode is based on https://dom.spec.whatwg.org/#dom-treewalker-nextnode
+func (w *treeWalker) NextNode() spec.Node {
+	node := w.current
+	result := spec.FilterAccept
+	for {
+		for result != spec.FilterReject && w.tree.firstChild(node) != nil {
+			node = w.tree.firstChild(node)
+			result = w.filterNode(node)
+			if result == spec.FilterAccept {
+				w.current = node
+				return w.tree.domNode(node)
+			}
+		}
+		var sibling *html.Node
+		for temporary := node; temporary != nil; temporary = w.tree.parent(temporary) {
+			if temporary == w.tree.root {
+				return nil
+			}
+			if sibling = w.tree.nextSibling(temporary); sibling != nil {
+				break
+			}
+		}
+		if sibling == nil {
+			return nil
+		}
+		node = sibling
+		result = w.filterNode(node)
+		if result == spec.FilterAccept {
+			w.current = node
+			return w.tree.domNode(node)
+		}
+	}
+}
+
+// nodeIterator is based on https://dom.spec.whatwg.org/#interface-nodeiterator
+type nodeIterator struct {
+	traversal
+	reference                  *html.Node
+	pointerBeforeReferenceNode bool
+}
+
+var _ spec.NodeIterator = (*nodeIterator)(nil)
+
+// nodeIterators holds every live nodeIterator so removals can update their reference nodes.
+var nodeIterators liveSet[nodeIterator]
+
+func newNodeIterator(root spec.Node, whatToShow spec.WhatToShow, filter spec.NodeFilter) *nodeIterator {
+	tree := newTraversalTree(root)
+	iterator := &nodeIterator{
+		traversal:                  traversal{tree: tree, whatToShow: whatToShow, filter: filter},
+		reference:                  tree.root,
+		pointerBeforeReferenceNode: true,
+	}
+	nodeIterators.add(iterator)
+	return iterator
+}
+
+func (it *nodeIterator) ReferenceNode() spec.Node         { return it.tree.domNode(it.reference) }
+func (it *nodeIterator) PointerBeforeReferenceNode() bool { return it.pointerBeforeReferenceNode }
+func (it *nodeIterator) NextNode() spec.Node              { return it.traverse(true) }
+func (it *nodeIterator) PreviousNode() spec.Node          { return it.traverse(false) }
+
+// traverse is based on https://dom.spec.whatwg.org/#concept-nodeiterator-traverse
+func (it *nodeIterator) traverse(next bool) spec.Node {
+	node := it.reference
+	beforeNode := it.pointerBeforeReferenceNode
+	for {
+		if next {
+			if !beforeNode {
+				if node = it.tree.following(node, false); node == nil {
+					return nil
+				}
+			} else {
+				beforeNode = false
+			}
+		} else {
+			if beforeNode {
+				if node = it.tree.preceding(node); node == nil {
+					return nil
+				}
+			} else {
+				beforeNode = true
+			}
+		}
+		if it.filterNode(node) == spec.FilterAccept {
+			break
+		}
+	}
+	it.reference = node
+	it.pointerBeforeReferenceNode = beforeNode
+	return it.tree.domNode(node)
+}
+
+// preRemove is based on https://dom.spec.whatwg.org/#nodeiterator-pre-removing-steps
+func (it *nodeIterator) preRemove(toBeRemoved *html.Node) {
+	if !it.tree.isInclusiveAncestor(toBeRemoved, it.reference) || it.tree.isInclusiveAncestor(toBeRemoved, it.tree.root) {
+		return
+	}
+	if it.pointerBeforeReferenceNode {
+		if next := it.tree.following(toBeRemoved, true); next != nil {
+			it.reference = next
+			return
+		}
+		it.pointerBeforeReferenceNode = false
+	}
+	if s := it.tree.previousSibling(toBeRemoved); s != nil {
+		it.reference = it.tree.lastInclusiveDescendant(s)
+	} else {
+		it.reference = it.tree.parent(toBeRemoved)
+	}
+}
+
+func nodeIteratorsPreRemove(node *html.Node) {
+	nodeIterators.each(func(it *nodeIterator) { it.preRemove(node) })
+}
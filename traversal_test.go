@@ -0,0 +1,209 @@
+package dom_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/net/html"
+
+	"github.com/typelate/dom"
+	"github.com/typelate/dom/spec"
+)
+
+func parseTraversalDocument(t *testing.T) spec.Document {
+	t.Helper()
+	// language=html
+	node, err := html.Parse(strings.NewReader(`<!DOCTYPE html><html><head></head><body><main id="root"><h1>Title</h1><!--note--><section><p>one</p><p class="skip">two <b>bold</b></p></section><footer>end</footer></main></body></html>`))
+	require.NoError(t, err)
+	return dom.NewNode(node).(spec.Document)
+}
+
+func nodeName(node spec.Node) string {
+	switch n := node.(type) {
+	case nil:
+		return ""
+	case spec.Element:
+		return strings.ToLower(n.TagName())
+	case spec.Text:
+		return "#" + n.Data()
+	case spec.Comment:
+		return "!" + n.Data()
+	case spec.DocumentFragment:
+		return "#fragment"
+	default:
+		return node.NodeType().String()
+	}
+}
+
+func collectNext(next func() spec.Node) []string {
+	var names []string
+	for n := next(); n != nil; n = next() {
+		names = append(names, nodeName(n))
+	}
+	return names
+}
+
+func TestTreeWalker(t *testing.T) {
+	t.Run("elements", func(t *testing.T) {
+		document := parseTraversalDocument(t)
+		root := document.GetElementById("root")
+		walker := document.CreateTreeWalker(root, spec.ShowElement, nil)
+
+		assert.True(t, walker.Root().IsSameNode(root))
+		assert.True(t, walker.CurrentNode().IsSameNode(root))
+		assert.Equal(t, []string{"h1", "section", "p", "p", "b", "footer"}, collectNext(walker.NextNode))
+		assert.Equal(t, "footer", nodeName(walker.CurrentNode()))
+		assert.Equal(t, []string{"b", "p", "p", "section", "h1", "main"}, collectNext(walker.PreviousNode))
+	})
+	t.Run("text and comments", func(t *testing.T) {
+		document := parseTraversalDocument(t)
+		walker := document.CreateTreeWalker(document.GetElementById("root"), spec.ShowText|spec.ShowComment, nil)
+		assert.Equal(t, []string{"#Title", "!note", "#one", "#two ", "#bold", "#end"}, collectNext(walker.NextNode))
+	})
+	t.Run("filter", func(t *testing.T) {
+		document := parseTraversalDocument(t)
+		root := document.GetElementById("root")
+		skip := spec.NodeFilterFunc(func(node spec.Node) spec.FilterResult {
+			if el, ok := node.(spec.Element); ok && el.ClassName() == "skip" {
+				return spec.FilterSkip
+			}
+			return spec.FilterAccept
+		})
+		reject := spec.NodeFilterFunc(func(node spec.Node) spec.FilterResult {
+			if el, ok := node.(spec.Element); ok && el.ClassName() == "skip" {
+				return spec.FilterReject
+			}
+			return spec.FilterAccept
+		})
+
+		assert.Equal(t, []string{"h1", "section", "p", "b", "footer"}, collectNext(document.CreateTreeWalker(root, spec.ShowElement, skip).NextNode))
+		assert.Equal(t, []string{"h1", "section", "p", "footer"}, collectNext(document.CreateTreeWalker(root, spec.ShowElement, reject).NextNode))
+	})
+	t.Run("navigation", func(t *testing.T) {
+		document := parseTraversalDocument(t)
+		root := document.GetElementById("root")
+		walker := document.CreateTreeWalker(root, spec.ShowElement, nil)
+
+		assert.Equal(t, "h1", nodeName(walker.FirstChild()))
+		assert.Equal(t, "section", nodeName(walker.NextSibling()))
+		assert.Equal(t, "footer", nodeName(walker.NextSibling()))
+		assert.Nil(t, walker.NextSibling())
+		assert.Equal(t, "section", nodeName(walker.PreviousSibling()))
+		assert.Equal(t, "p", nodeName(walker.LastChild()))
+		assert.Equal(t, "skip", walker.CurrentNode().(spec.Element).ClassName())
+		assert.Equal(t, "section", nodeName(walker.ParentNode()))
+		assert.Equal(t, "main", nodeName(walker.ParentNode()))
+		assert.Nil(t, walker.ParentNode())
+		assert.Equal(t, "main", nodeName(walker.CurrentNode()))
+
+		walker.SetCurrentNode(root.QuerySelector("b"))
+		assert.Equal(t, "footer", nodeName(walker.NextNode()))
+	})
+	t.Run("skipped children", func(t *testing.T) {
+		document := parseTraversalDocument(t)
+		section := document.GetElementById("root").QuerySelector("section")
+		onlyBold := spec.NodeFilterFunc(func(node spec.Node) spec.FilterResult {
+			if nodeName(node) == "b" {
+				return spec.FilterAccept
+			}
+			return spec.FilterSkip
+		})
+		walker := document.CreateTreeWalker(section, spec.ShowElement, onlyBold)
+		assert.Equal(t, "b", nodeName(walker.FirstChild()))
+		walker.SetCurrentNode(section)
+		assert.Equal(t, "b", nodeName(walker.LastChild()))
+	})
+	t.Run("fragment", func(t *testing.T) {
+		fragment := parseDocumentFragment(t, `a<p>b</p><!--c-->`)
+		var document *dom.Document
+		walker := document.CreateTreeWalker(fragment, spec.ShowAll, nil)
+
+		assert.Equal(t, []string{"#a", "p", "#b", "!c"}, collectNext(walker.NextNode))
+		assert.Equal(t, []string{"#b", "p", "#a", "#fragment"}, collectNext(walker.PreviousNode))
+		assert.Equal(t, "!c", nodeName(walker.LastChild()))
+		assert.Equal(t, "p", nodeName(walker.PreviousSibling()))
+		assert.Equal(t, "#fragment", nodeName(walker.ParentNode()))
+	})
+	t.Run("reentrant filter", func(t *testing.T) {
+		document := parseTraversalDocument(t)
+		var walker spec.TreeWalker
+		walker = document.CreateTreeWalker(document.GetElementById("root"), spec.ShowElement, spec.NodeFilterFunc(func(spec.Node) spec.FilterResult {
+			walker.NextNode()
+			return spec.FilterAccept
+		}))
+		assert.Panics(t, func() { walker.NextNode() })
+	})
+}
+
+func TestNodeIterator(t *testing.T) {
+	t.Run("next and previous", func(t *testing.T) {
+		document := parseTraversalDocument(t)
+		root := document.GetElementById("root")
+		iterator := document.CreateNodeIterator(root, spec.ShowElement, nil)
+
+		assert.True(t, iterator.Root().IsSameNode(root))
+		assert.True(t, iterator.PointerBeforeReferenceNode())
+		assert.Equal(t, []string{"main", "h1", "section", "p", "p", "b", "footer"}, collectNext(iterator.NextNode))
+		assert.False(t, iterator.PointerBeforeReferenceNode())
+		assert.Equal(t, []string{"footer", "b", "p", "p", "section", "h1", "main"}, collectNext(iterator.PreviousNode))
+		assert.True(t, iterator.PointerBeforeReferenceNode())
+	})
+	t.Run("reject does not skip children", func(t *testing.T) {
+		document := parseTraversalDocument(t)
+		reject := spec.NodeFilterFunc(func(node spec.Node) spec.FilterResult {
+			if el, ok := node.(spec.Element); ok && el.ClassName() == "skip" {
+				return spec.FilterReject
+			}
+			return spec.FilterAccept
+		})
+		iterator := document.CreateNodeIterator(document.GetElementById("root"), spec.ShowElement, reject)
+		assert.Equal(t, []string{"main", "h1", "section", "p", "b", "footer"}, collectNext(iterator.NextNode))
+	})
+	t.Run("remove reference node", func(t *testing.T) {
+		document := parseTraversalDocument(t)
+		root := document.GetElementById("root")
+		iterator := document.CreateNodeIterator(root, spec.ShowElement, nil)
+
+		assert.Equal(t, "main", nodeName(iterator.NextNode()))
+		assert.Equal(t, "h1", nodeName(iterator.NextNode()))
+		section := iterator.NextNode().(spec.Element)
+		assert.Equal(t, "section", nodeName(section))
+
+		section.Remove()
+
+		assert.Equal(t, "!note", nodeName(iterator.ReferenceNode()))
+		assert.Equal(t, "footer", nodeName(iterator.NextNode()))
+		assert.Nil(t, iterator.NextNode())
+	})
+	t.Run("remove reference node before pointer", func(t *testing.T) {
+		document := parseTraversalDocument(t)
+		root := document.GetElementById("root")
+		iterator := document.CreateNodeIterator(root, spec.ShowElement, nil)
+
+		for range 3 {
+			iterator.NextNode()
+		}
+		assert.Equal(t, "section", nodeName(iterator.PreviousNode()))
+		assert.True(t, iterator.PointerBeforeReferenceNode())
+
+		root.RemoveChild(root.QuerySelector("section"))
+
+		assert.Equal(t, "footer", nodeName(iterator.ReferenceNode()))
+		assert.Equal(t, "footer", nodeName(iterator.NextNode()))
+		assert.Equal(t, "footer", nodeName(iterator.PreviousNode()))
+		assert.Equal(t, "h1", nodeName(iterator.PreviousNode()))
+	})
+	t.Run("set inner html", func(t *testing.T) {
+		document := parseTraversalDocument(t)
+		root := document.GetElementById("root")
+		iterator := document.CreateNodeIterator(root, spec.ShowElement, nil)
+		for range 4 {
+			iterator.NextNode()
+		}
+		root.SetInnerHTML(`<p>new</p>`)
+		assert.Equal(t, "main", nodeName(iterator.ReferenceNode()))
+		assert.Equal(t, "p", nodeName(iterator.NextNode()))
+	})
+}